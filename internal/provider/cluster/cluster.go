@@ -270,9 +270,9 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.Errorf("error waiting for cluster: %s", err)
 	}
 
-	cluster, err := c.GetCluster(ctx, cr.ClusterID)
+	cluster, err := c.WaitForClusterStatus(ctx, cr.ClusterID, "ACTIVE", clusterPollInterval)
 	if err != nil {
-		return diag.Errorf("error reading cluster: %s", err)
+		return diag.Errorf("error waiting for cluster: %s", err)
 	}
 
 	i := p.InstanceByID(cluster.Datacenter.InstanceID)
@@ -319,7 +319,7 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, meta inter
 
 	cluster, err := c.GetCluster(ctx, clusterID)
 	if err != nil {
-		if scylla.IsClusterDeletedErr(err) {
+		if scylla.IsClusterDeletedErr(err) || scylla.IsNotFound(err) {
 			d.SetId("")
 			return nil // cluster was deleted
 		}
@@ -414,31 +414,16 @@ func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.Errorf("delete request failure: %q", r.UserFriendlyError)
 	}
 
+	if err := c.WaitForClusterDeletion(ctx, clusterID, clusterPollInterval); err != nil {
+		return diag.Errorf("error waiting for cluster deletion: %s", err)
+	}
+
 	return nil
 }
 
 func WaitForCluster(ctx context.Context, c *scylla.Client, requestID int64) error {
-	t := time.NewTicker(clusterPollInterval)
-	defer t.Stop()
-
-	for range t.C {
-		r, err := c.GetClusterRequest(ctx, requestID)
-		if err != nil {
-			return fmt.Errorf("error reading cluster request: %w", err)
-		}
-
-		if strings.EqualFold(r.Status, "COMPLETED") {
-			break
-		} else if strings.EqualFold(r.Status, "QUEUED") || strings.EqualFold(r.Status, "IN_PROGRESS") {
-			continue
-		} else if strings.EqualFold(r.Status, "FAILED") {
-			return fmt.Errorf("cluster request failed: %q", r.UserFriendlyError)
-		}
-
-		return fmt.Errorf("unrecognized cluster request status: %q", r.Status)
-	}
-
-	return nil
+	_, err := c.WaitForClusterRequest(ctx, requestID, clusterPollInterval)
+	return err
 }
 
 func resourceClusterUpgradeV0(ctx context.Context, rawState map[string]any, meta any) (map[string]any, error) {
@@ -60,6 +60,11 @@ func DataSourceCQLAuth() *schema.Resource {
 				Computed:    true,
 				Type:        schema.TypeString,
 			},
+			"port": {
+				Description: "CQL port",
+				Computed:    true,
+				Type:        schema.TypeInt,
+			},
 			"username": {
 				Description: "CQL username",
 				Computed:    true,
@@ -103,6 +108,7 @@ func dataSourceCQLAuthRead(ctx context.Context, d *schema.ResourceData, meta int
 	_ = d.Set("username", conn.Credentials.Username)
 	_ = d.Set("password", conn.Credentials.Password)
 	_ = d.Set("seeds", seeds)
+	_ = d.Set("port", model.CQLPort)
 
 	return nil
 }
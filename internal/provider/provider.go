@@ -2,6 +2,9 @@ package provider
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"os"
 	"runtime"
 
@@ -15,12 +18,17 @@ import (
 	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var defaultEndpoint = "https://api.cloud.scylladb.com"
 
+// Version is the provider version reported in the User-Agent header of
+// API requests, it is set by main from the build version.
+var Version = "dev"
+
 func envToken() string {
 	return os.Getenv("SCYLLADB_CLOUD_TOKEN")
 }
@@ -55,6 +63,16 @@ func New(context.Context) (*schema.Provider, error) {
 				},
 				Description: "Bearer token used to authenticate with the API.",
 			},
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of the account to manage, the default account of the token owner is used if not set.",
+			},
+			"ca_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM file with additional certificate authorities to trust when connecting to the API.",
+			},
 			"metadata": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -87,27 +105,66 @@ func New(context.Context) (*schema.Provider, error) {
 
 func configure(ctx context.Context, p *schema.Provider, d *schema.ResourceData) (*scylla.Client, diag.Diagnostics) {
 	var (
-		endpoint = d.Get("endpoint").(string)
-		token    = d.Get("token").(string)
-		metadata = d.Get("metadata").(bool)
+		endpoint  = d.Get("endpoint").(string)
+		token     = d.Get("token").(string)
+		metadata  = d.Get("metadata").(bool)
+		accountID = d.Get("account_id").(int)
+		caFile    = d.Get("ca_file").(string)
+		opts      []func(*scylla.Client)
 	)
 
-	c, err := scylla.NewClient(endpoint, token, userAgent(p.TerraformVersion), metadata)
+	if accountID != 0 {
+		opts = append(opts, scylla.WithAccountID(int64(accountID)))
+	}
+
+	if caFile != "" {
+		pool, err := loadRootCAs(caFile)
+		if err != nil {
+			return nil, diag.Errorf("could not load certificate authorities: %s", err)
+		}
+		opts = append(opts, scylla.WithRootCAs(pool))
+	}
+
+	c, err := scylla.NewClient(ctx, endpoint, token, userAgent(p.TerraformVersion), metadata, opts...)
+	if errors.Is(err, scylla.ErrUnauthorized) {
+		return nil, diag.Errorf("could not create new Scylla client: the API token is invalid or expired: %s", err)
+	}
 	if err != nil {
 		return nil, diag.Errorf("could not create new Scylla client: %s", err)
 	}
 
+	tflog.Info(ctx, "configured Scylla Cloud client", map[string]interface{}{
+		"accountId": c.AccountID,
+		"endpoint":  c.Endpoint.String(),
+	})
+
 	return c, nil
 }
 
-func userAgent(tfVersion string) string {
-	sysinfo := "(" + runtime.GOOS + "/" + runtime.GOARCH + ")"
+// loadRootCAs returns the system certificate pool extended with
+// the certificates of the PEM file.
+func loadRootCAs(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
 
-	if tfVersion != "" {
-		return "Terraform/" + tfVersion + "(" + sysinfo + ")"
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
 	}
 
-	return "Terraform/0.11+compatible (" + sysinfo + ")"
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %q", file)
+	}
+
+	return pool, nil
+}
+
+func userAgent(tfVersion string) string {
+	sysinfo := "(" + runtime.Version() + "; " + runtime.GOOS + "/" + runtime.GOARCH + ")"
+
+	return "terraform-provider-scylladbcloud/" + Version + " Terraform/" + nonempty(tfVersion, "0.11+compatible") + " " + sysinfo
 }
 
 func nonempty[T comparable](t ...T) T {
@@ -0,0 +1,69 @@
+package scylla
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxAuditLogPeriod limits the period of a single audit log export.
+const maxAuditLogPeriod = 31 * 24 * time.Hour
+
+// ExportAuditLog downloads the audit log of the cluster for the [start, end)
+// period. The export is verified against the checksum reported by the API,
+// which is returned alongside the data.
+func (c *Client) ExportAuditLog(ctx context.Context, clusterID int64, start, end time.Time) ([]byte, string, error) {
+	switch {
+	case !start.Before(end):
+		return nil, "", errors.New("audit log period start must be before its end")
+	case end.After(time.Now()):
+		return nil, "", errors.New("audit log period must not end in the future")
+	case end.Sub(start) > maxAuditLogPeriod:
+		return nil, "", fmt.Errorf("audit log period must not be longer than %s", maxAuditLogPeriod)
+	}
+
+	var (
+		data   []byte
+		result struct {
+			Checksum string `json:"checksum"`
+		}
+		path  = fmt.Sprintf("/account/%d/cluster/%d/audit-log", c.AccountID, clusterID)
+		query = []string{
+			"start", start.UTC().Format(time.RFC3339),
+			"end", end.UTC().Format(time.RFC3339),
+		}
+	)
+
+	if err := c.get(ctx, path, &data, query...); err != nil {
+		return nil, "", err
+	}
+
+	if err := c.get(ctx, path+"/checksum", &result, query...); err != nil {
+		return nil, "", fmt.Errorf("error reading audit log checksum: %w", err)
+	}
+
+	if err := VerifyChecksum(data, result.Checksum); err != nil {
+		return nil, "", err
+	}
+
+	return data, result.Checksum, nil
+}
+
+// Checksum returns the checksum of data in the "sha256:<hex>" form.
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// VerifyChecksum returns an error if the checksum of data does not match
+// the expected one.
+func VerifyChecksum(data []byte, checksum string) error {
+	if got := Checksum(data); !strings.EqualFold(got, checksum) {
+		return fmt.Errorf("checksum mismatch: got %s, want %s", got, checksum)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package scylla
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestExportAuditLog(t *testing.T) {
+	data := []byte("2024-06-01T10:00:00Z AUTH scylla LOGIN\n")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/audit-log", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("start") == "" || r.URL.Query().Get("end") == "" {
+			t.Errorf("missing period in query: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write(data)
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/audit-log/checksum", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]string{"checksum": Checksum(data)})
+	})
+
+	c := newTestClient(t, mux)
+
+	end := time.Now().Add(-time.Hour)
+	start := end.Add(-24 * time.Hour)
+
+	got, sum, err := c.ExportAuditLog(context.Background(), 1, start, end)
+	if err != nil {
+		t.Fatalf("ExportAuditLog()=%+v", err)
+	}
+	if string(got) != string(data) || sum != Checksum(data) {
+		t.Fatalf("unexpected export: %q, %q", got, sum)
+	}
+
+	if _, _, err := c.ExportAuditLog(context.Background(), 1, end, start); err == nil {
+		t.Fatal("want error for reversed period, got nil")
+	}
+
+	if _, _, err := c.ExportAuditLog(context.Background(), 1, start, time.Now().Add(time.Hour)); err == nil {
+		t.Fatal("want error for period ending in the future, got nil")
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("audit")
+
+	if err := VerifyChecksum(data, Checksum(data)); err != nil {
+		t.Fatalf("VerifyChecksum()=%+v", err)
+	}
+
+	if err := VerifyChecksum([]byte("tampered"), Checksum(data)); err == nil {
+		t.Fatal("want checksum mismatch, got nil")
+	}
+}
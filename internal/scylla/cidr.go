@@ -0,0 +1,122 @@
+package scylla
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// privateBlocks lists the RFC 1918 address blocks.
+var privateBlocks = []netip.Prefix{
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+}
+
+func parseCIDRs(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+
+	for _, s := range cidrs {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", s, err)
+		}
+		prefixes = append(prefixes, p.Masked())
+	}
+
+	return prefixes, nil
+}
+
+// SuggestClusterCIDR returns the first IPv4 block of the given prefix length
+// within the RFC 1918 address space that does not overlap any of the
+// existing CIDRs.
+func SuggestClusterCIDR(existing []string, prefixLen int) (string, error) {
+	if prefixLen < 8 || prefixLen > 28 {
+		return "", fmt.Errorf("invalid prefix length %d, expected a value between 8 and 28", prefixLen)
+	}
+
+	taken, err := parseCIDRs(existing)
+	if err != nil {
+		return "", err
+	}
+
+	size := uint64(1) << (32 - prefixLen)
+
+	for _, block := range privateBlocks {
+		if prefixLen < block.Bits() {
+			continue
+		}
+
+		var (
+			start = addrToUint(block.Addr())
+			end   = start + uint64(1)<<(32-block.Bits())
+		)
+
+		for cand := start; cand < end; {
+			p := netip.PrefixFrom(uintToAddr(cand), prefixLen)
+
+			o, ok := firstOverlap(p, taken)
+			if !ok {
+				return p.String(), nil
+			}
+
+			// Skip past the overlapping block, keeping the candidate aligned.
+			next := lastAddr(o) + 1
+			next = (next + size - 1) / size * size
+			cand = max(cand+size, next)
+		}
+	}
+
+	return "", errors.New("no free private address block of the requested size")
+}
+
+// ValidateMultiRegionCIDRs checks that the CIDRs of the datacenters of
+// a multi-region cluster can be routed together, that is no two of them
+// overlap. The error names the conflicting pair.
+func ValidateMultiRegionCIDRs(cidrs []string) error {
+	prefixes, err := parseCIDRs(cidrs)
+	if err != nil {
+		return err
+	}
+
+	for i := range prefixes {
+		for j := i + 1; j < len(prefixes); j++ {
+			p, q := prefixes[i], prefixes[j]
+
+			switch {
+			case !p.Overlaps(q):
+				continue
+			case p == q:
+				return fmt.Errorf("CIDR %s is used more than once", cidrs[i])
+			case p.Bits() < q.Bits():
+				return fmt.Errorf("CIDR %s is a subset of %s", cidrs[j], cidrs[i])
+			case p.Bits() > q.Bits():
+				return fmt.Errorf("CIDR %s is a subset of %s", cidrs[i], cidrs[j])
+			}
+		}
+	}
+
+	return nil
+}
+
+func firstOverlap(p netip.Prefix, prefixes []netip.Prefix) (netip.Prefix, bool) {
+	for _, q := range prefixes {
+		if p.Overlaps(q) {
+			return q, true
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+func addrToUint(a netip.Addr) uint64 {
+	b := a.As4()
+	return uint64(b[0])<<24 | uint64(b[1])<<16 | uint64(b[2])<<8 | uint64(b[3])
+}
+
+func uintToAddr(n uint64) netip.Addr {
+	return netip.AddrFrom4([4]byte{byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)})
+}
+
+func lastAddr(p netip.Prefix) uint64 {
+	return addrToUint(p.Addr()) + uint64(1)<<(32-p.Bits()) - 1
+}
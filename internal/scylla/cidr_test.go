@@ -0,0 +1,114 @@
+package scylla
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuggestClusterCIDR(t *testing.T) {
+	cases := []struct {
+		name      string
+		existing  []string
+		prefixLen int
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "no existing networks",
+			prefixLen: 16,
+			want:      "10.0.0.0/16",
+		},
+		{
+			name:      "avoids overlaps",
+			existing:  []string{"10.0.0.0/16", "10.1.128.0/17", "192.168.0.0/16"},
+			prefixLen: 16,
+			want:      "10.2.0.0/16",
+		},
+		{
+			name:      "smaller block fills a gap",
+			existing:  []string{"10.0.0.0/24", "10.0.2.0/24"},
+			prefixLen: 24,
+			want:      "10.0.1.0/24",
+		},
+		{
+			name:      "falls back to next private block",
+			existing:  []string{"10.0.0.0/8"},
+			prefixLen: 16,
+			want:      "172.16.0.0/16",
+		},
+		{
+			name:      "exhausted space",
+			existing:  []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"},
+			prefixLen: 24,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid prefix length",
+			prefixLen: 4,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid existing cidr",
+			existing:  []string{"10.0.0.0/33"},
+			prefixLen: 16,
+			wantErr:   true,
+		},
+	}
+
+	for _, cas := range cases {
+		t.Run(cas.name, func(t *testing.T) {
+			got, err := SuggestClusterCIDR(cas.existing, cas.prefixLen)
+			if (err != nil) != cas.wantErr {
+				t.Fatalf("SuggestClusterCIDR()=%q, %v, wantErr %t", got, err, cas.wantErr)
+			}
+			if got != cas.want {
+				t.Fatalf("SuggestClusterCIDR()=%q, want %q", got, cas.want)
+			}
+		})
+	}
+}
+
+func TestValidateMultiRegionCIDRs(t *testing.T) {
+	cases := []struct {
+		name    string
+		cidrs   []string
+		wantErr string
+	}{
+		{
+			name:  "disjoint",
+			cidrs: []string{"10.0.0.0/16", "10.1.0.0/16", "172.16.0.0/24"},
+		},
+		{
+			name:    "subset",
+			cidrs:   []string{"10.0.0.0/16", "172.16.0.0/24", "10.0.4.0/24"},
+			wantErr: "CIDR 10.0.4.0/24 is a subset of 10.0.0.0/16",
+		},
+		{
+			name:    "superset",
+			cidrs:   []string{"10.0.4.0/24", "10.0.0.0/8"},
+			wantErr: "CIDR 10.0.4.0/24 is a subset of 10.0.0.0/8",
+		},
+		{
+			name:    "duplicate",
+			cidrs:   []string{"10.0.0.0/16", "10.0.0.0/16"},
+			wantErr: "CIDR 10.0.0.0/16 is used more than once",
+		},
+		{
+			name:    "invalid",
+			cidrs:   []string{"10.0.0.0/33"},
+			wantErr: "invalid CIDR",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateMultiRegionCIDRs(tc.cidrs)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("ValidateMultiRegionCIDRs()=%+v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Fatalf("want error %q, got %+v", tc.wantErr, err)
+			}
+		})
+	}
+}
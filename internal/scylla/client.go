@@ -10,6 +10,9 @@ import (
 	"net/http"
 	"net/url"
 	stdpath "path"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	v2scylla "github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/v2"
@@ -25,6 +28,9 @@ const (
 	maxResponseBodyLength int64 = 1 << 20
 )
 
+// secretRegexp matches JSON encoded secrets, which must not be logged.
+var secretRegexp = regexp.MustCompile(`"(password|bearerToken|api_key)"\s*:\s*"[^"]*"`)
+
 // Client represents a client to call the Scylla Cloud API
 type Client struct {
 	Meta *Cloudmeta
@@ -48,26 +54,69 @@ type Client struct {
 	// AccountID holds the account ID used in requests to the API.
 	AccountID int64
 
-	// Retry is used to retry requests to the API.
+	// Retry is used to retry requests to the API. Set it to a retrier
+	// with no backoff to fail fast.
 	Retry *retrier.Retrier
 
+	// RetryNonIdempotent enables retrying POST and PATCH requests, which
+	// may be applied twice if the server fails after processing them.
+	RetryNonIdempotent bool
+
 	// V2 is the client to call the V2 API, it does not require costly
 	// metadata building.
 	V2 *v2scylla.Client
+
+	// timeDelta is the offset of the server clock from the local one, it is
+	// measured from the Date header of the first response by setTimeDelta.
+	timeDeltaMutex sync.Mutex
+	timeDeltaDone  bool
+	timeDelta      time.Duration
 }
 
-func NewClient(endpoint, token, useragent string, metadata bool) (*Client, error) {
-	errCodes, err := parse(codes, codesDelim, codesFunc)
+// Option configures a Client created with NewClient or
+// NewClientWithOptions.
+type Option = func(*Client)
+
+// NewClient creates a client of the API at endpoint. When metadata is true
+// it also reads the cloud provider metadata and, unless it is set with
+// WithAccountID, the default account, using ctx for these calls. The
+// options are applied before any call is made.
+func NewClient(ctx context.Context, endpoint, token, useragent string, metadata bool, opts ...Option) (*Client, error) {
+	end, err := url.Parse(endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse error codes: %w", err)
+		return nil, err
 	}
 
-	end, err := url.Parse(endpoint)
+	opts = append([]Option{WithEndpoint(end), WithUserAgent(useragent)}, opts...)
+
+	c, err := NewClientWithOptions(token, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx := context.Background()
+	if metadata {
+		if c.Meta, err = BuildCloudmeta(ctx, c); err != nil {
+			return nil, fmt.Errorf("error building metadata: %w", err)
+		}
+		if c.AccountID == 0 {
+			if err = c.findAndSaveAccountID(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// NewClientWithOptions creates a client of the API at the endpoint set with
+// WithEndpoint. It makes no calls to the API, so the account must be set
+// with WithAccountID and the metadata, if needed, built with BuildCloudmeta.
+func NewClientWithOptions(token string, opts ...Option) (*Client, error) {
+	errCodes, err := parse(codes, codesDelim, codesFunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse error codes: %w", err)
+	}
+
 	retry := retrier.New(
 		retrier.ExponentialBackoff(5, 5*time.Second),
 		DefaultClassifier,
@@ -79,26 +128,27 @@ func NewClient(endpoint, token, useragent string, metadata bool) (*Client, error
 		Headers:    make(http.Header),
 		HTTPClient: &http.Client{Timeout: defaultTimeout},
 		Retry:      retry,
-		Endpoint:   end,
 		V2: v2scylla.New(
 			v2scylla.WithRetryPolicy(retry),
-			v2scylla.WithUserAgent(useragent),
-			v2scylla.WithBaseURL(endpoint),
 			v2scylla.WithGlobalCookieJar(),
 		),
 	}
 
 	c.Headers.Set("Authorization", "Bearer "+c.Token)
 	c.Headers.Set("Accept", "application/json; charset=utf-8")
-	c.Headers.Set("User-Agent", useragent)
 
-	if metadata {
-		if c.Meta, err = BuildCloudmeta(ctx, c); err != nil {
-			return nil, fmt.Errorf("error building metadata: %w", err)
-		}
-		if err = c.findAndSaveAccountID(ctx); err != nil {
-			return nil, err
-		}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.Endpoint == nil {
+		return nil, errors.New("no API endpoint, set one with WithEndpoint")
+	}
+
+	// The V2 client takes the endpoint and user agent the options settled on.
+	v2scylla.WithBaseURL(c.Endpoint.String())(c.V2)
+	if ua := c.Headers.Get("User-Agent"); ua != "" {
+		v2scylla.WithUserAgent(ua)(c.V2)
 	}
 
 	return c, nil
@@ -150,14 +200,64 @@ func (c *Client) newHttpRequest(ctx context.Context, method, path string, reqBod
 	return req, nil
 }
 
-func (c *Client) retryCall(ctx context.Context, mathod, path string, reqBody, resType interface{}, query ...string) error {
+// maxRetryAfter caps the time a retry waits for as requested by
+// the Retry-After header.
+const maxRetryAfter = time.Minute
+
+func (c *Client) retryCall(ctx context.Context, method, path string, reqBody, resType interface{}, query ...string) error {
+	if !c.RetryNonIdempotent && !isIdempotent(method) {
+		return c.call(ctx, method, path, reqBody, resType, query...)
+	}
+
+	var (
+		lastErr  error
+		failedAt time.Time
+	)
+
 	return c.Retry.RunCtx(ctx, func(ctx context.Context) error {
-		return c.call(ctx, mathod, path, reqBody, resType, query...)
+		// The retrier gets here again only if it decided to retry the
+		// last error, after waiting for its own backoff.
+		if d := retryAfterDelay(lastErr, failedAt); d > 0 {
+			t := time.NewTimer(d)
+			defer t.Stop()
+
+			select {
+			case <-ctx.Done():
+				return errors.Join(lastErr, ctx.Err())
+			case <-t.C:
+			}
+		}
+
+		lastErr = c.call(ctx, method, path, reqBody, resType, query...)
+		failedAt = time.Now()
+
+		return lastErr
 	})
 }
 
+// retryAfterDelay returns how much longer to wait before retrying a call
+// that failed with err at failedAt. The Retry-After header of the error
+// takes the place of the retrier backoff, which has already elapsed, so
+// only the remainder is returned.
+func retryAfterDelay(err error, failedAt time.Time) time.Duration {
+	if e := new(APIError); errors.As(err, &e) && e.RetryAfter > 0 {
+		return min(e.RetryAfter, maxRetryAfter) - time.Since(failedAt)
+	}
+	return 0
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
 func (c *Client) call(ctx context.Context, method, path string, reqBody, resType interface{}, query ...string) error {
-	ctx = tfcontext.AddHttpRequestInfo(ctx, method, path)
+	// Secrets are masked in every call, the bodies may be logged below.
+	ctx = maskSecrets(tfcontext.AddHttpRequestInfo(ctx, method, path))
 
 	req, err := c.newHttpRequest(ctx, method, path, reqBody, query...)
 	if err != nil {
@@ -165,11 +265,30 @@ func (c *Client) call(ctx context.Context, method, path string, reqBody, resType
 	}
 	req = req.WithContext(ctx)
 
+	start := time.Now()
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
+		tflog.Debug(ctx, "api call failed: "+req.Method+" "+req.URL.String(), map[string]interface{}{
+			"duration": time.Since(start).String(),
+			"error":    err.Error(),
+		})
+
 		return err
 	}
-	defer resp.Body.Close()
+	c.setTimeDelta(resp)
+
+	tflog.Debug(ctx, "api call completed: "+req.Method+" "+req.URL.String(), map[string]interface{}{
+		"code":     resp.StatusCode,
+		"duration": time.Since(start).String(),
+	})
+
+	defer func() {
+		// Drain what the decoder left unread, e.g. the trailing newline,
+		// so the connection can be reused.
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodyLength))
+		resp.Body.Close()
+	}()
 
 	var (
 		buf  bytes.Buffer
@@ -190,6 +309,20 @@ func (c *Client) call(ctx context.Context, method, path string, reqBody, resType
 			return fmt.Errorf("error reading body: %w", err)
 		}
 
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			err := makeError(rawErrorText(*p, resp.StatusCode), buf.String(), c.ErrCodes, resp)
+			*p = nil
+
+			tflog.Trace(ctx, "api returned error: "+err.Error(), map[string]interface{}{
+				"code":   resp.StatusCode,
+				"status": resp.Status,
+				"body":   buf.String(),
+				"error":  err.Error(),
+			})
+
+			return err
+		}
+
 		tflog.Trace(ctx, "api call succeeded", map[string]interface{}{
 			"code":   resp.StatusCode,
 			"status": resp.Status,
@@ -215,9 +348,16 @@ func (c *Client) call(ctx context.Context, method, path string, reqBody, resType
 			"body":   buf.String(),
 		})
 
-		err = makeError("failed to unmarshal data: "+err.Error(), c.ErrCodes, resp)
+		text := "failed to unmarshal data: " + err.Error()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			// Not a JSON error, e.g. an HTML page served by a proxy,
+			// so report the body as is.
+			if body := strings.TrimSpace(buf.String()); body != "" {
+				text = body
+			}
+		}
 
-		return err
+		return makeError(text, buf.String(), c.ErrCodes, resp)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
@@ -227,7 +367,7 @@ func (c *Client) call(ctx context.Context, method, path string, reqBody, resType
 	}
 
 	if data.Error != "" {
-		err = makeError(data.Error, c.ErrCodes, resp)
+		err = makeError(data.Error, buf.String(), c.ErrCodes, resp)
 
 		tflog.Trace(ctx, "api returned error: "+err.Error(), map[string]interface{}{
 			"code":   resp.StatusCode,
@@ -248,6 +388,59 @@ func (c *Client) call(ctx context.Context, method, path string, reqBody, resType
 	return nil
 }
 
+// rawErrorText returns the error of a failed call whose body is read as
+// is: the error of a JSON envelope, else the body itself, else the status.
+func rawErrorText(body []byte, status int) string {
+	var data struct {
+		Error string `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &data); err == nil && data.Error != "" {
+		return data.Error
+	}
+
+	if text := strings.TrimSpace(string(body)); text != "" {
+		return text
+	}
+
+	return http.StatusText(status)
+}
+
+// setTimeDelta measures the server clock offset from the Date header of
+// the first response that carries one.
+func (c *Client) setTimeDelta(resp *http.Response) {
+	c.timeDeltaMutex.Lock()
+	defer c.timeDeltaMutex.Unlock()
+
+	if c.timeDeltaDone {
+		return
+	}
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+
+	c.timeDelta = time.Until(date)
+	c.timeDeltaDone = true
+}
+
+// ServerTime returns the current time according to the server clock. Until
+// the first response is received it returns the local time.
+func (c *Client) ServerTime() time.Time {
+	c.timeDeltaMutex.Lock()
+	defer c.timeDeltaMutex.Unlock()
+
+	return time.Now().Add(c.timeDelta)
+}
+
+// maskSecrets masks secrets, such as passwords, in the request and response
+// bodies logged by the calls made with the returned context. Headers,
+// including Authorization, are never logged.
+func maskSecrets(ctx context.Context) context.Context {
+	return tflog.MaskAllFieldValuesRegexes(ctx, secretRegexp)
+}
+
 func (c *Client) get(ctx context.Context, path string, resultType interface{}, query ...string) error {
 	return c.retryCall(ctx, http.MethodGet, path, nil, resultType, query...)
 }
@@ -256,6 +449,10 @@ func (c *Client) post(ctx context.Context, path string, requestBody, resultType
 	return c.retryCall(ctx, http.MethodPost, path, requestBody, resultType)
 }
 
+func (c *Client) put(ctx context.Context, path string, requestBody, resultType interface{}) error {
+	return c.retryCall(ctx, http.MethodPut, path, requestBody, resultType)
+}
+
 func (c *Client) patch(ctx context.Context, path string, requestBody, resultType interface{}) error {
 	return c.retryCall(ctx, http.MethodPatch, path, requestBody, resultType)
 }
@@ -270,7 +467,7 @@ func (c *Client) findAndSaveAccountID(ctx context.Context) error {
 	}
 
 	if err := c.get(ctx, "/account/default", &result); err != nil {
-		return err
+		return fmt.Errorf("failed to read default account: %w", err)
 	}
 
 	c.AccountID = result.AccountID
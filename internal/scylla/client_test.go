@@ -0,0 +1,479 @@
+package scylla
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+
+	"github.com/eapache/go-resiliency/retrier"
+)
+
+const testAccountID = 1
+
+func newTestClient(t *testing.T, h http.Handler) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(context.Background(), srv.URL, "token", "test", false)
+	if err != nil {
+		t.Fatalf("NewClient()=%+v", err)
+	}
+
+	c.AccountID = testAccountID
+	c.Retry = retrier.New(retrier.ConstantBackoff(2, time.Millisecond), DefaultClassifier)
+
+	return c
+}
+
+// testCreateRequest returns a cluster create request with all the required
+// fields set.
+func testCreateRequest(name string) model.ClusterCreateRequest {
+	return model.ClusterCreateRequest{
+		ClusterName:       name,
+		CloudProviderID:   1,
+		RegionID:          1,
+		InstanceID:        10,
+		NumberOfNodes:     3,
+		ReplicationFactor: 3,
+	}
+}
+
+func writeData(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": v})
+}
+
+func serveFixture(fixture string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fixture))
+	}
+}
+
+// serveTestdata serves the named file of the testdata directory, which
+// holds a complete response, envelope included.
+func serveTestdata(t *testing.T, name string) http.HandlerFunc {
+	t.Helper()
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("ReadFile()=%+v", err)
+	}
+
+	return serveFixture(string(fixture))
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": code})
+}
+
+func readBody(t *testing.T, r *http.Request, v interface{}) {
+	t.Helper()
+
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		t.Errorf("error decoding request body: %+v", err)
+	}
+}
+
+func TestNewClientRetriesAccountBootstrap(t *testing.T) {
+	var calls int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /deployment/scylla-versions", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ScyllaVersions{})
+	})
+	mux.HandleFunc("GET /deployment/cloud-providers", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.CloudProviders{})
+	})
+	mux.HandleFunc("GET /account/default", func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls <= 2 {
+			writeError(w, http.StatusServiceUnavailable, "Service Unavailable")
+			return
+		}
+		writeData(w, map[string]int64{"accountId": 123})
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	fastRetry := func(c *Client) {
+		c.Retry = retrier.New(retrier.ConstantBackoff(retriesAllowed, time.Millisecond), DefaultClassifier)
+	}
+
+	c, err := NewClient(context.Background(), srv.URL, "token", "test", true, fastRetry)
+	if err != nil {
+		t.Fatalf("NewClient()=%+v", err)
+	}
+
+	if c.AccountID != 123 {
+		t.Fatalf("want account ID %d, got %d", 123, c.AccountID)
+	}
+
+	if calls != 3 {
+		t.Fatalf("want %d calls, got %d", 3, calls)
+	}
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/7/clusters", func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "test" {
+			t.Errorf("want user agent %q, got %q", "test", ua)
+		}
+		writeData(w, model.Clusters{})
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("Parse()=%+v", err)
+	}
+
+	c, err := NewClientWithOptions("token",
+		WithEndpoint(u),
+		WithUserAgent("test"),
+		WithAccountID(7),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions()=%+v", err)
+	}
+
+	if _, err := c.ListClusters(context.Background()); err != nil {
+		t.Fatalf("ListClusters()=%+v", err)
+	}
+
+	if _, err := NewClientWithOptions("token", WithAccountID(7)); err == nil {
+		t.Fatal("want error for missing endpoint, got nil")
+	}
+}
+
+func TestNewClientCanceled(t *testing.T) {
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		writeData(w, nil)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := NewClient(ctx, srv.URL, "token", "test", true); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %+v", err)
+	}
+
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("want no calls, got %d", n)
+	}
+}
+
+func TestSecretRegexp(t *testing.T) {
+	body := `{"data":{"username":"scylla","password":"p4ss","bearerToken":"t0ken","config":{"api_key":"k3y"}}}`
+
+	masked := secretRegexp.ReplaceAllString(body, "***")
+
+	for _, secret := range []string{"p4ss", "t0ken", "k3y"} {
+		if strings.Contains(masked, secret) {
+			t.Fatalf("want %q masked, got %s", secret, masked)
+		}
+	}
+	if !strings.Contains(masked, "scylla") {
+		t.Fatalf("want username kept, got %s", masked)
+	}
+}
+
+func TestRequestMethods(t *testing.T) {
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	mux := http.NewServeMux()
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch} {
+		mux.HandleFunc(method+" /resource", func(w http.ResponseWriter, r *http.Request) {
+			if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+				t.Errorf("%s: want JSON content type, got %q", r.Method, ct)
+			}
+			var req payload
+			readBody(t, r, &req)
+			writeData(w, payload{Name: r.Method + " " + req.Name})
+		})
+	}
+	mux.HandleFunc("DELETE /resource", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+	ctx := context.Background()
+
+	for method, call := range map[string]func(string, interface{}, interface{}) error{
+		http.MethodPost: func(path string, body, result interface{}) error {
+			return c.post(ctx, path, body, result)
+		},
+		http.MethodPut: func(path string, body, result interface{}) error {
+			return c.put(ctx, path, body, result)
+		},
+		http.MethodPatch: func(path string, body, result interface{}) error {
+			return c.patch(ctx, path, body, result)
+		},
+	} {
+		var got payload
+		if err := call("/resource", &payload{Name: "x"}, &got); err != nil {
+			t.Fatalf("%s()=%+v", method, err)
+		}
+		if want := method + " x"; got.Name != want {
+			t.Fatalf("want %q, got %q", want, got.Name)
+		}
+	}
+
+	if err := c.delete(ctx, "/resource"); err != nil {
+		t.Fatalf("delete()=%+v", err)
+	}
+
+	if err := c.put(ctx, "/missing", &payload{}, nil); !IsNotFound(err) {
+		t.Fatalf("want not found error, got %+v", err)
+	}
+}
+
+func TestClientReusesConnections(t *testing.T) {
+	var conns atomic.Int32
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.Clusters{})
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(context.Background(), srv.URL, "token", "test", false)
+	if err != nil {
+		t.Fatalf("NewClient()=%+v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := c.ListClusters(context.Background()); err != nil {
+			t.Fatalf("ListClusters()=%+v", err)
+		}
+	}
+
+	if n := conns.Load(); n != 1 {
+		t.Fatalf("want 1 connection, got %d", n)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		writeError(w, http.StatusConflict, "CLUSTER_BUSY")
+	})
+	mux.HandleFunc("GET /html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html>502 Bad Gateway</html>\n"))
+	})
+
+	c := newTestClient(t, mux)
+	c.Retry = retrier.New(nil, DefaultClassifier)
+
+	var e *APIError
+
+	if err := c.get(context.Background(), "/json", nil); !errors.As(err, &e) {
+		t.Fatalf("want *APIError, got %+v", err)
+	}
+	if e.StatusCode != http.StatusConflict || e.Message != "CLUSTER_BUSY" || e.RequestID != "req-1" {
+		t.Fatalf("unexpected error: %+v", e)
+	}
+	if !strings.Contains(e.Error(), "request ID req-1") {
+		t.Fatalf("want request ID in %q", e.Error())
+	}
+
+	if err := c.get(context.Background(), "/html", nil); !errors.As(err, &e) {
+		t.Fatalf("want *APIError, got %+v", err)
+	}
+	if e.StatusCode != http.StatusBadGateway || e.Message != "<html>502 Bad Gateway</html>" {
+		t.Fatalf("unexpected error: %+v", e)
+	}
+	if e.Raw() != "<html>502 Bad Gateway</html>\n" {
+		t.Fatalf("want the raw body kept, got %q", e.Raw())
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{status}", func(w http.ResponseWriter, r *http.Request) {
+		status, _ := strconv.Atoi(r.PathValue("status"))
+		writeError(w, status, http.StatusText(status))
+	})
+
+	c := newTestClient(t, mux)
+	c.Retry = retrier.New(nil, DefaultClassifier)
+
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrForbidden}
+
+	cases := map[int]error{
+		http.StatusNotFound:     ErrNotFound,
+		http.StatusUnauthorized: ErrUnauthorized,
+		http.StatusForbidden:    ErrForbidden,
+		http.StatusConflict:     nil,
+	}
+
+	for status, want := range cases {
+		err := c.get(context.Background(), "/"+strconv.Itoa(status), nil)
+		if err == nil {
+			t.Fatalf("GET %d: want error", status)
+		}
+
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == want) {
+				t.Errorf("GET %d: errors.Is(err, %v)=%t", status, sentinel, got)
+			}
+		}
+	}
+}
+
+func TestRawCallError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/bundle", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "040001")
+	})
+	mux.HandleFunc("GET /account/1/cluster/2/bundle", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<html>Access denied</html>"))
+	})
+
+	c := newTestClient(t, mux)
+
+	if b, err := c.Bundle(context.Background(), 1); !errors.Is(err, ErrNotFound) || b != nil {
+		t.Fatalf("Bundle()=%q, %+v, want ErrNotFound", b, err)
+	}
+
+	b, err := c.Bundle(context.Background(), 2)
+	if !errors.Is(err, ErrForbidden) || b != nil {
+		t.Fatalf("Bundle()=%q, %+v, want ErrForbidden", b, err)
+	}
+	if !strings.Contains(err.Error(), "Access denied") {
+		t.Fatalf("want the body in the error, got %+v", err)
+	}
+}
+
+func TestServerTime(t *testing.T) {
+	skew := 2 * time.Hour
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(skew).UTC().Format(http.TimeFormat))
+		writeData(w, model.Clusters{})
+	}))
+
+	if d := time.Until(c.ServerTime()); d > time.Second || d < -time.Second {
+		t.Fatalf("want local time before the first call, got offset %s", d)
+	}
+
+	if _, err := c.ListClusters(context.Background()); err != nil {
+		t.Fatalf("ListClusters()=%+v", err)
+	}
+
+	if d := time.Until(c.ServerTime()) - skew; d > 2*time.Second || d < -2*time.Second {
+		t.Fatalf("want server time %s ahead, got offset %s", skew, time.Until(c.ServerTime()))
+	}
+}
+
+func TestRetryCall(t *testing.T) {
+	calls := make(map[string]int)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/flaky", func(w http.ResponseWriter, r *http.Request) {
+		if calls[r.Method]++; calls[r.Method] == 1 {
+			w.Header().Set("Retry-After", r.URL.Query().Get("after"))
+			writeError(w, http.StatusServiceUnavailable, "Service Unavailable")
+			return
+		}
+		writeData(w, nil)
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Method]++
+		w.Header().Set("Retry-After", "60")
+		writeError(w, http.StatusInternalServerError, "Internal Server Error")
+	})
+
+	c := newTestClient(t, mux)
+
+	if err := c.get(context.Background(), "/flaky", nil); err != nil {
+		t.Fatalf("get()=%+v", err)
+	}
+	if calls[http.MethodGet] != 2 {
+		t.Fatalf("want GET retried once, got %d calls", calls[http.MethodGet])
+	}
+
+	if err := c.post(context.Background(), "/flaky", nil, nil); err == nil {
+		t.Fatal("want POST error, got nil")
+	}
+	if calls[http.MethodPost] != 1 {
+		t.Fatalf("want POST not retried, got %d calls", calls[http.MethodPost])
+	}
+
+	// A Retry-After of a minute is waited for before the next attempt.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	calls = make(map[string]int)
+
+	if err := c.get(ctx, "/flaky", nil, "after", "60"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %+v", err)
+	}
+
+	// Errors that are not retried return at once despite Retry-After.
+	calls = make(map[string]int)
+	start := time.Now()
+
+	if err := c.get(context.Background(), "/broken", nil); err == nil {
+		t.Fatal("want error, got nil")
+	}
+	if calls[http.MethodGet] != 1 {
+		t.Fatalf("want GET not retried, got %d calls", calls[http.MethodGet])
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("want no wait for Retry-After, took %s", d)
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 50 * time.Millisecond})
+
+	if d := retryAfterDelay(err, time.Now()); d <= 40*time.Millisecond || d > 50*time.Millisecond {
+		t.Fatalf("want delay close to 50ms, got %s", d)
+	}
+	if d := retryAfterDelay(err, time.Now().Add(-time.Second)); d > 0 {
+		t.Fatalf("want no delay once the backoff exceeded Retry-After, got %s", d)
+	}
+	if d := retryAfterDelay(&APIError{StatusCode: http.StatusServiceUnavailable}, time.Now()); d != 0 {
+		t.Fatalf("want no delay without Retry-After, got %s", d)
+	}
+	if d := retryAfterDelay(nil, time.Time{}); d != 0 {
+		t.Fatalf("want no delay for the first attempt, got %s", d)
+	}
+}
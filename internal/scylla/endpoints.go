@@ -1,11 +1,23 @@
 package scylla
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 func (c *Client) ListCloudProviders(ctx context.Context) ([]model.CloudProvider, error) {
@@ -37,259 +49,1523 @@ func (c *Client) ListScyllaVersions(ctx context.Context) (*model.ScyllaVersions,
 	return &result, nil
 }
 
-func (c *Client) ListCloudProviderInstances(ctx context.Context, providerID int64) ([]model.CloudProviderInstance, error) {
-	var result model.CloudProviderRegions
-	path := fmt.Sprintf("/deployment/cloud-provider/%d/regions", providerID)
-	if err := c.get(ctx, path, &result, "defaults", "true"); err != nil {
+// ListScyllaVersionsByEdition returns the Scylla versions of the given
+// edition, either model.EditionEnterprise or model.EditionOSS.
+func (c *Client) ListScyllaVersionsByEdition(ctx context.Context, edition string) ([]model.ScyllaVersion, error) {
+	if edition = strings.ToLower(edition); edition != model.EditionEnterprise && edition != model.EditionOSS {
+		return nil, fmt.Errorf("unsupported edition %q, expected one of: %s, %s", edition, model.EditionEnterprise, model.EditionOSS)
+	}
+
+	versions, err := c.ListScyllaVersions(ctx)
+	if err != nil {
 		return nil, err
 	}
-	return result.Instances, nil
+
+	var result []model.ScyllaVersion
+	for i := range versions.ScyllaVersions {
+		if versions.ScyllaVersions[i].GetEdition() == edition {
+			result = append(result, versions.ScyllaVersions[i])
+		}
+	}
+
+	return result, nil
 }
 
-func (c *Client) GetCluster(ctx context.Context, clusterID int64) (*model.Cluster, error) {
-	var result struct {
-		Cluster model.Cluster `json:"cluster"`
+// LatestScyllaVersion returns the newest Scylla version that new clusters
+// can be created with.
+func (c *Client) LatestScyllaVersion(ctx context.Context) (*model.ScyllaVersion, error) {
+	versions, err := c.ListScyllaVersions(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	path := fmt.Sprintf("/account/%d/cluster/%d", c.AccountID, clusterID)
-	err := c.get(ctx, path, &result, "enriched", "true")
+	var latest *model.ScyllaVersion
+
+	for i := range versions.ScyllaVersions {
+		v := &versions.ScyllaVersions[i]
+
+		if !strings.EqualFold(v.NewCluster, "ENABLED") {
+			continue
+		}
+		if latest == nil || compareVersions(v.Version, latest.Version) > 0 {
+			latest = v
+		}
+	}
+
+	if latest == nil {
+		return nil, errors.New("no Scylla version is enabled for new clusters")
+	}
 
-	return &result.Cluster, err
+	return latest, nil
 }
 
-func (c *Client) Bundle(ctx context.Context, clusterID int64) ([]byte, error) {
-	var raw []byte
+// compareVersions compares dotted version strings, such as "2024.1.3",
+// numerically part by part. A pre-release suffix, like "-rc1", sorts the
+// version before its release.
+func compareVersions(a, b string) int {
+	a, preA, _ := strings.Cut(a, "-")
+	b, preB, _ := strings.Cut(b, "-")
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/bundle", c.AccountID, clusterID)
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
 
-	if err := c.get(ctx, path, &raw); err != nil {
-		return nil, err
+	for i := 0; i < max(len(partsA), len(partsB)); i++ {
+		var x, y int
+		if i < len(partsA) {
+			x, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			y, _ = strconv.Atoi(partsB[i])
+		}
+		if x != y {
+			return cmp.Compare(x, y)
+		}
 	}
 
-	return raw, nil
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+
+	return strings.Compare(preA, preB)
 }
 
-func (c *Client) Connect(ctx context.Context, clusterID int64) (*model.ClusterConnectionInformation, error) {
-	var result model.ClusterConnectionInformation
+func (c *Client) ListCloudProviderInstances(ctx context.Context, providerID int64) ([]model.CloudProviderInstance, error) {
+	var result model.CloudProviderRegions
+	path := fmt.Sprintf("/deployment/cloud-provider/%d/regions", providerID)
+	if err := c.get(ctx, path, &result, "defaults", "true"); err != nil {
+		return nil, err
+	}
+	return result.Instances, nil
+}
 
-	path := fmt.Sprintf("/account/%d/cluster/connect", c.AccountID)
+// ListInstanceTypes returns the instance types available in the region of
+// the cloud provider.
+func (c *Client) ListInstanceTypes(ctx context.Context, providerID, regionID int64) ([]model.CloudProviderInstance, error) {
+	var result model.CloudProviderInstances
+	path := fmt.Sprintf("/deployment/cloud-provider/%d/region/%d", providerID, regionID)
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return result.Instances, nil
+}
 
-	if err := c.get(ctx, path, &result, "clusterId", strconv.FormatInt(clusterID, 10)); err != nil {
+func (c *Client) ListInstanceTypesByArch(ctx context.Context, providerID, regionID int64, arch string) ([]model.CloudProviderInstance, error) {
+	if arch = strings.ToLower(arch); arch != model.ArchitectureX86 && arch != model.ArchitectureARM {
+		return nil, fmt.Errorf("unsupported architecture %q, expected one of: %s, %s", arch, model.ArchitectureX86, model.ArchitectureARM)
+	}
+
+	instances, err := c.ListInstanceTypes(ctx, providerID, regionID)
+	if err != nil {
 		return nil, err
 	}
 
-	fix_sf3112(&result) // TODO(rjeczalik): remove when scylladb/siren-frontend#3112 gets fixed
+	var result []model.CloudProviderInstance
+	for i := range instances {
+		if instances[i].Arch() == arch {
+			result = append(result, instances[i])
+		}
+	}
 
-	return &result, nil
+	return result, nil
 }
 
-func (c *Client) CreateCluster(ctx context.Context, req *model.ClusterCreateRequest) (*model.ClusterRequest, error) {
-	var result struct {
-		RequestID int64 `json:"requestId"`
-	}
+// instanceTypeAliases maps legacy instance type external IDs to the
+// instance types that replaced them.
+var instanceTypeAliases = map[string]string{
+	"i3.large":     "i3en.large",
+	"i3.xlarge":    "i3en.xlarge",
+	"i3.2xlarge":   "i3en.2xlarge",
+	"n1-highmem-2": "n2-highmem-2",
+	"n1-highmem-4": "n2-highmem-4",
+	"n1-highmem-8": "n2-highmem-8",
+}
 
-	path := fmt.Sprintf("/account/%d/cluster", c.AccountID)
+// maxInstanceTypeSuggestions limits the number of instance types suggested
+// for an unknown alias.
+const maxInstanceTypeSuggestions = 5
 
-	if err := c.post(ctx, path, req, &result); err != nil {
+// ResolveInstanceTypeAlias returns the instance type of the region with
+// the given external ID, or the one that replaced it if the ID is a known
+// legacy alias. For unknown IDs the error lists similar instance types.
+func (c *Client) ResolveInstanceTypeAlias(ctx context.Context, providerID, regionID int64, alias string) (*model.CloudProviderInstance, error) {
+	instances, err := c.ListInstanceTypes(ctx, providerID, regionID)
+	if err != nil {
 		return nil, err
 	}
 
-	var clusterReq model.ClusterRequest
+	name := strings.ToLower(strings.TrimSpace(alias))
+	if current, ok := instanceTypeAliases[name]; ok {
+		name = current
+	}
 
-	path = fmt.Sprintf("/account/%d/cluster/request/%d", c.AccountID, result.RequestID)
+	for i := range instances {
+		if strings.EqualFold(instances[i].ExternalID, name) {
+			return &instances[i], nil
+		}
+	}
 
-	if err := c.get(ctx, path, &clusterReq); err != nil {
-		return nil, err
+	var all, similar []string
+	for i := range instances {
+		all = append(all, instances[i].ExternalID)
+		if instanceFamily(instances[i].ExternalID) == instanceFamily(name) {
+			similar = append(similar, instances[i].ExternalID)
+		}
 	}
 
-	return &clusterReq, nil
-}
+	if len(similar) == 0 {
+		similar = all
+	}
 
-func (c *Client) DeleteCluster(ctx context.Context, clusterID int64, clusterName string) (*model.ClusterRequest, error) {
-	var result model.ClusterRequest
+	sort.Strings(similar)
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/delete", c.AccountID, clusterID)
-	data := map[string]interface{}{
-		"clusterName": clusterName,
+	if len(similar) > maxInstanceTypeSuggestions {
+		similar = similar[:maxInstanceTypeSuggestions]
 	}
 
-	if err := c.post(ctx, path, data, &result); err != nil {
+	return nil, fmt.Errorf("unknown instance type %q, did you mean one of: %s", alias, strings.Join(similar, ", "))
+}
+
+// ListAccounts returns the accounts the token has access to.
+func (c *Client) ListAccounts(ctx context.Context) ([]model.UserAccount, error) {
+	var result []model.UserAccount
+
+	if err := c.get(ctx, "/account", &result); err != nil {
 		return nil, err
 	}
-	return &result, nil
+
+	return result, nil
 }
 
-func (c *Client) ListClusters(ctx context.Context) ([]model.Cluster, error) {
-	var result model.Clusters
+func (c *Client) ListIdentityProviders(ctx context.Context) ([]model.IdentityProvider, error) {
+	var result struct {
+		Providers []model.IdentityProvider `json:"identityProviders"`
+	}
 
-	path := fmt.Sprintf("/account/%d/clusters", c.AccountID)
+	path := fmt.Sprintf("/account/%d/sso/identity-providers", c.AccountID)
 
-	if err := c.get(ctx, path, &result, "enriched", "true"); err != nil {
+	if err := c.get(ctx, path, &result); err != nil {
 		return nil, err
 	}
 
-	return result.Clusters, nil
+	return result.Providers, nil
 }
 
-func (c *Client) ListClusterRequest(ctx context.Context, clusterID int64, typ string) ([]model.ClusterRequest, error) {
-	var (
-		result []model.ClusterRequest
-		query  []string
-	)
-
-	if typ != "" {
-		query = append(query, "type", typ)
+func (c *Client) GetCluster(ctx context.Context, clusterID int64) (*model.Cluster, error) {
+	var result struct {
+		Cluster model.Cluster `json:"cluster"`
 	}
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/request", c.AccountID, clusterID)
+	path := fmt.Sprintf("/account/%d/cluster/%d", c.AccountID, clusterID)
 
-	if err := c.get(ctx, path, &result, query...); err != nil {
+	if err := c.get(ctx, path, &result, "enriched", "true"); err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	return &result.Cluster, nil
 }
 
-func (c *Client) GetClusterRequest(ctx context.Context, requestID int64) (model.ClusterRequest, error) {
-	var result model.ClusterRequest
-	path := fmt.Sprintf("/account/%d/cluster/request/%d", c.AccountID, requestID)
-	err := c.get(ctx, path, &result)
-	return result, err
+// CanAccessCluster reports whether the token is allowed to access the
+// cluster. If access is forbidden it returns false along with the reason
+// reported by the API. A cluster that does not exist results in an error
+// for which IsNotFound reports true.
+func (c *Client) CanAccessCluster(ctx context.Context, clusterID int64) (bool, string, error) {
+	if _, err := c.GetCluster(ctx, clusterID); err != nil {
+		if e := new(APIError); IsForbidden(err) && errors.As(err, &e) {
+			return false, e.Message, nil
+		}
+		return false, "", err
+	}
+
+	return true, "", nil
 }
 
-func (c *Client) ListAllowlistRules(ctx context.Context, clusterID int64) ([]model.AllowedIP, error) {
-	var result []model.AllowedIP
+func (c *Client) Bundle(ctx context.Context, clusterID int64) ([]byte, error) {
+	var raw []byte
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/firewall/allowed", c.AccountID, clusterID)
+	path := fmt.Sprintf("/account/%d/cluster/%d/bundle", c.AccountID, clusterID)
 
-	if err := c.get(ctx, path, &result); err != nil {
+	if err := c.get(ctx, path, &raw); err != nil {
 		return nil, err
 	}
-	return result, nil
-}
 
-func (c *Client) CreateAllowlistRule(ctx context.Context, clusterID int64, address string) ([]model.AllowedIP, error) {
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/firewall/allowed", c.AccountID, clusterID)
+	return raw, nil
+}
 
-	var result []model.AllowedIP
+func (c *Client) Connect(ctx context.Context, clusterID int64) (*model.ClusterConnectionInformation, error) {
+	var result model.ClusterConnectionInformation
 
-	data := map[string]interface{}{
-		"ipAddress": address,
-	}
+	path := fmt.Sprintf("/account/%d/cluster/connect", c.AccountID)
 
-	if err := c.post(ctx, path, data, &result); err != nil {
+	if err := c.get(ctx, path, &result, "clusterId", strconv.FormatInt(clusterID, 10)); err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	fix_sf3112(&result) // TODO(rjeczalik): remove when scylladb/siren-frontend#3112 gets fixed
+
+	return &result, nil
 }
 
-func (c *Client) DeleteAllowlistRule(ctx context.Context, clusterID, ruleID int64) error {
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/firewall/allowed/%d", c.AccountID, clusterID, ruleID)
+// GetConnectionsBatch reads connection details of each of the given clusters
+// concurrently. Failures of individual clusters do not affect the other
+// results; they are joined into the returned error, each naming its cluster.
+// If the context is done first, the clusters not started yet are skipped
+// and the context error is joined as well.
+func (c *Client) GetConnectionsBatch(ctx context.Context, clusterIDs []int64) (map[int64]*model.ClusterConnectionInformation, error) {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, bulkConcurrency)
+		conns  = make(map[int64]*model.ClusterConnectionInformation)
+		errs   = make(map[int64]error)
+		ctxErr error
+	)
 
-	return c.delete(ctx, path)
-}
+	for _, id := range clusterIDs {
+		if ctxErr = acquire(ctx, sem); ctxErr != nil {
+			break
+		}
 
-func (c *Client) ListDataCenters(ctx context.Context, clusterID int64) ([]model.Datacenter, error) {
-	var result model.Datacenters
+		wg.Add(1)
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/dcs", c.AccountID, clusterID)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
 
-	if err := c.get(ctx, path, &result, "enriched", "true"); err != nil {
-		return nil, err
+			conn, err := c.Connect(ctx, id)
+
+			mu.Lock()
+			if err != nil {
+				errs[id] = err
+			} else {
+				conns[id] = conn
+			}
+			mu.Unlock()
+		}()
 	}
 
-	return result.Datacenters, nil
+	wg.Wait()
+
+	all := make([]error, 0, len(errs)+1)
+	for _, id := range clusterIDs {
+		if err := errs[id]; err != nil {
+			all = append(all, fmt.Errorf("cluster %d: %w", id, err))
+		}
+	}
+
+	return conns, errors.Join(append(all, ctxErr)...)
 }
 
-func (c *Client) ListClusterNodes(ctx context.Context, clusterID int64) ([]model.Node, error) {
-	var result model.Nodes
+func (c *Client) GetInitialCredentials(ctx context.Context, clusterID int64) (*model.InitialCredentials, error) {
+	var result model.InitialCredentials
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/nodes", c.AccountID, clusterID)
+	path := fmt.Sprintf("/account/%d/cluster/%d/credentials/initial", c.AccountID, clusterID)
 
-	if err := c.get(ctx, path, &result, "enriched", "true"); err != nil {
+	if err := c.get(ctx, path, &result); err != nil {
+		if e := new(APIError); errors.As(err, &e) && e.StatusCode == http.StatusGone {
+			return nil, fmt.Errorf("cluster %d: %w", clusterID, ErrCredentialAlreadyRetrieved)
+		}
 		return nil, err
 	}
 
-	return result.Nodes, nil
+	return &result, nil
 }
 
-func (c *Client) ListClusterVPCPeerings(ctx context.Context, clusterID int64) ([]model.VPCPeering, error) {
-	var result []model.VPCPeering
+// CanDeployInRegion reports whether the account is entitled to deploy
+// clusters in the given region. For gated regions the returned reason
+// explains why.
+func (c *Client) CanDeployInRegion(ctx context.Context, providerID, regionID int64) (bool, string, error) {
+	var result struct {
+		Entitled bool   `json:"entitled"`
+		Reason   string `json:"reason"`
+	}
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/peer", c.AccountID, clusterID)
+	path := fmt.Sprintf("/account/%d/deployment/cloud-provider/%d/region/%d/entitlement", c.AccountID, providerID, regionID)
 
 	if err := c.get(ctx, path, &result); err != nil {
-		return nil, err
+		if IsNotFound(err) {
+			return true, "", nil // region is not gated
+		}
+		return false, "", err
 	}
 
-	return result, nil
+	return result.Entitled, result.Reason, nil
 }
 
-func (c *Client) CreateClusterVPCPeering(ctx context.Context, clusterID int64, req *model.VPCPeeringRequest) (*model.VPCPeering, error) {
+func (c *Client) GetMandatoryTags(ctx context.Context) ([]string, error) {
 	var result struct {
-		ID         int64  `json:"id"`
-		ExternalID string `json:"externalId"`
+		Keys []string `json:"keys"`
 	}
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/peer", c.AccountID, clusterID)
+	path := fmt.Sprintf("/account/%d/tags/mandatory", c.AccountID)
 
-	if err := c.post(ctx, path, req, &result); err != nil {
+	if err := c.get(ctx, path, &result); err != nil {
+		if IsNotFound(err) {
+			return nil, nil // account does not enforce tags
+		}
 		return nil, err
 	}
 
-	return c.GetClusterVPCPeering(ctx, clusterID, result.ID)
+	return result.Keys, nil
 }
 
-func (c *Client) GetClusterVPCPeering(ctx context.Context, clusterID, peerID int64) (*model.VPCPeering, error) {
-	var result model.VPCPeering
+// IsClusterNameAvailable reports whether no existing cluster, including
+// ones pending deletion, uses the given name.
+func (c *Client) IsClusterNameAvailable(ctx context.Context, name string) (bool, error) {
+	clusters, err := c.ListClusters(ctx)
+	if err != nil {
+		return false, err
+	}
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/peer/%d", c.AccountID, clusterID, peerID)
+	for i := range clusters {
+		if strings.EqualFold(clusters[i].ClusterName, name) && !strings.EqualFold(clusters[i].Status, "DELETED") {
+			return false, nil
+		}
+	}
 
-	if err := c.get(ctx, path, &result); err != nil {
-		return nil, err
+	pending, err := c.ListPendingDeletions(ctx)
+	if err != nil && !IsNotFound(err) {
+		return false, err
 	}
 
-	return &result, nil
+	for i := range pending {
+		if strings.EqualFold(pending[i].ClusterName, name) {
+			return false, nil
+		}
+	}
+
+	return true, nil
 }
 
-func (c *Client) DeleteClusterVPCPeering(ctx context.Context, clusterID, peerID int64) error {
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/peer/%d", c.AccountID, clusterID, peerID)
+// validateCreateRequest runs the account-level checks that are cheaper to
+// do before sending a create request than to wait for it to fail. Only a
+// definite answer rejects the request; a check that cannot be completed is
+// skipped and left to the API.
+func (c *Client) validateCreateRequest(ctx context.Context, req *model.ClusterCreateRequest) error {
+	if req.CloudProviderID != 0 && req.RegionID != 0 {
+		ok, reason, err := c.CanDeployInRegion(ctx, req.CloudProviderID, req.RegionID)
+		switch {
+		case err != nil:
+			skipCreateCheck(ctx, "region entitlement", err)
+		case !ok:
+			return fmt.Errorf("account is not entitled to deploy in region %d: %s", req.RegionID, reason)
+		}
+	}
 
-	return c.delete(ctx, path)
+	if req.ClusterName != "" {
+		ok, err := c.IsClusterNameAvailable(ctx, req.ClusterName)
+		switch {
+		case err != nil:
+			skipCreateCheck(ctx, "cluster name", err)
+		case !ok:
+			return fmt.Errorf("cluster name %q is already taken", req.ClusterName)
+		}
+	}
+
+	keys, err := c.GetMandatoryTags(ctx)
+	if err != nil {
+		skipCreateCheck(ctx, "mandatory tags", err)
+		return nil
+	}
+
+	var missing []string
+	for _, k := range keys {
+		if _, ok := req.Tags[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("missing mandatory tag(s): %s", strings.Join(missing, ", "))
+	}
+
+	return nil
 }
 
-func (c *Client) CreateClusterConnection(ctx context.Context, clusterID int64, req *model.ClusterConnectionCreateRequest) (*model.ClusterConnection, error) {
+func skipCreateCheck(ctx context.Context, check string, err error) {
+	tflog.Warn(ctx, "skipping "+check+" check before create", map[string]interface{}{
+		"error": err.Error(),
+	})
+}
+
+func (c *Client) CreateCluster(ctx context.Context, req *model.ClusterCreateRequest) (*model.ClusterRequest, error) {
 	var result struct {
-		ID           int64 `json:"id"`
-		ConnectionID int64 `json:"connectionID"`
+		RequestID int64 `json:"requestId"`
 	}
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/connection", c.AccountID, clusterID)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if req.FreeTier {
+		if err := c.validateFreeTier(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.validateCreateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster", c.AccountID)
 
 	if err := c.post(ctx, path, req, &result); err != nil {
 		return nil, err
 	}
-	return c.GetClusterConnection(ctx, clusterID, result.ConnectionID)
-}
 
-func (c *Client) GetClusterConnection(ctx context.Context, clusterID, connectionID int64) (*model.ClusterConnection, error) {
-	var result model.ClusterConnection
+	var clusterReq model.ClusterRequest
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/connection/%d", c.AccountID, clusterID, connectionID)
+	path = fmt.Sprintf("/account/%d/cluster/request/%d", c.AccountID, result.RequestID)
 
-	if err := c.get(ctx, path, &result); err != nil {
+	if err := c.get(ctx, path, &clusterReq); err != nil {
 		return nil, err
 	}
-	return &result, nil
+
+	return &clusterReq, nil
 }
 
-func (c *Client) ListClusterConnections(ctx context.Context, clusterID int64) ([]model.ClusterConnection, error) {
-	result := struct {
-		Connections []model.ClusterConnection
-	}{}
+func (c *Client) ListClusterTemplates(ctx context.Context) ([]model.ClusterTemplate, error) {
+	var result struct {
+		Templates []model.ClusterTemplate `json:"templates"`
+	}
 
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/connection", c.AccountID, clusterID)
+	path := fmt.Sprintf("/account/%d/cluster/templates", c.AccountID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Templates, nil
+}
+
+// CreateClusterFromTemplate creates a cluster from the spec of the template,
+// with the non-zero fields of overrides applied on top of it. It returns
+// the ID of the cluster creation request.
+func (c *Client) CreateClusterFromTemplate(ctx context.Context, templateID int64, overrides *model.ClusterCreateRequest) (int64, error) {
+	templates, err := c.ListClusterTemplates(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range templates {
+		if templates[i].ID != templateID {
+			continue
+		}
+
+		req := templates[i].Spec.Merge(overrides)
+
+		r, err := c.CreateCluster(ctx, &req)
+		if err != nil {
+			return 0, err
+		}
+
+		return r.ID, nil
+	}
+
+	return 0, fmt.Errorf("cluster template %d not found", templateID)
+}
+
+func (c *Client) GetTerminationProtection(ctx context.Context, clusterID int64) (bool, error) {
+	var result struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/termination-protection", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return false, err
+	}
+
+	return result.Enabled, nil
+}
+
+func (c *Client) SetTerminationProtection(ctx context.Context, clusterID int64, enabled bool) error {
+	path := fmt.Sprintf("/account/%d/cluster/%d/termination-protection", c.AccountID, clusterID)
+	data := map[string]interface{}{
+		"enabled": enabled,
+	}
+
+	return c.patch(ctx, path, data, nil)
+}
+
+// DeleteCluster deletes the cluster. It returns an error wrapping
+// ErrTerminationProtected if the cluster has termination protection enabled.
+// If the protection cannot be read, the delete goes ahead and is left to
+// the API.
+func (c *Client) DeleteCluster(ctx context.Context, clusterID int64, clusterName string) (*model.ClusterRequest, error) {
+	protected, err := c.GetTerminationProtection(ctx, clusterID)
+	if err != nil && !IsNotFound(err) {
+		tflog.Warn(ctx, "unable to read termination protection, deleting anyway", map[string]interface{}{
+			"clusterId": clusterID,
+			"error":     err.Error(),
+		})
+	}
+
+	if protected {
+		return nil, fmt.Errorf("cluster %d: %w", clusterID, ErrTerminationProtected)
+	}
+
+	var result model.ClusterRequest
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/delete", c.AccountID, clusterID)
+	data := map[string]interface{}{
+		"clusterName": clusterName,
+	}
+
+	if err := c.post(ctx, path, data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CheckDeletePreconditions lists the resources that depend on the cluster
+// and would be affected by deleting it: VPC peerings, resource shares and
+// running Scylla Manager tasks. An empty result means the cluster can be
+// deleted cleanly.
+func (c *Client) CheckDeletePreconditions(ctx context.Context, clusterID int64) ([]string, error) {
+	var deps []string
+
+	peerings, err := c.ListClusterVPCPeerings(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing VPC peerings: %w", err)
+	}
+
+	for _, p := range peerings {
+		if !strings.EqualFold(p.Status, "DELETED") {
+			deps = append(deps, fmt.Sprintf("VPC peering %d with %s", p.ID, p.VPCID))
+		}
+	}
+
+	shares, err := c.ListResourceShares(ctx, clusterID)
+	if err != nil && !IsNotFound(err) {
+		return nil, fmt.Errorf("error listing resource shares: %w", err)
+	}
+
+	for _, s := range shares {
+		deps = append(deps, fmt.Sprintf("%s share with account %d", s.Permission, s.TargetAccountID))
+	}
+
+	tasks, err := c.ListManagerTasks(ctx, clusterID)
+	if err != nil && !IsNotFound(err) {
+		return nil, fmt.Errorf("error listing Scylla Manager tasks: %w", err)
+	}
+
+	for _, t := range tasks {
+		if strings.EqualFold(t.Status, "RUNNING") {
+			deps = append(deps, fmt.Sprintf("running %s task %s", t.Type, t.ID))
+		}
+	}
+
+	return deps, nil
+}
+
+// ListOptions controls a single page read of a list endpoint.
+type ListOptions struct {
+	// PageSize is the maximum number of items in the page, zero means
+	// the API default.
+	PageSize int
+	// Cursor is the NextPageToken of the previous page, empty for the
+	// first page.
+	Cursor string
+}
+
+// ListClusters returns all clusters of the account, following the pages
+// until the last one.
+func (c *Client) ListClusters(ctx context.Context) ([]model.Cluster, error) {
+	var (
+		clusters []model.Cluster
+		seen     = make(map[string]bool)
+		opts     ListOptions
+	)
+
+	for {
+		page, err := c.ListClustersPage(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		clusters = append(clusters, page.Clusters...)
+
+		if page.NextPageToken == "" {
+			return clusters, nil
+		}
+		if seen[page.NextPageToken] {
+			return nil, fmt.Errorf("list clusters: page token %q repeated", page.NextPageToken)
+		}
+
+		seen[page.NextPageToken] = true
+		opts.Cursor = page.NextPageToken
+	}
+}
+
+// ListClustersPage returns a single page of clusters. The NextPageToken
+// of the result is empty when there are no more pages.
+func (c *Client) ListClustersPage(ctx context.Context, opts ListOptions) (*model.Clusters, error) {
+	var result model.Clusters
+
+	path := fmt.Sprintf("/account/%d/clusters", c.AccountID)
+
+	query := []string{"enriched", "true"}
+	if opts.PageSize > 0 {
+		query = append(query, "pageSize", strconv.Itoa(opts.PageSize))
+	}
+	if opts.Cursor != "" {
+		query = append(query, "pageToken", opts.Cursor)
+	}
+
+	if err := c.get(ctx, path, &result, query...); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) ListPendingDeletions(ctx context.Context) ([]model.PendingDeletion, error) {
+	var result struct {
+		Clusters []model.PendingDeletion `json:"clusters"`
+	}
+
+	path := fmt.Sprintf("/account/%d/clusters/pending-deletion", c.AccountID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Clusters, nil
+}
+
+// RecoverCluster cancels the deletion of a cluster, which is possible
+// until its recovery window closes.
+func (c *Client) RecoverCluster(ctx context.Context, clusterID int64) error {
+	pending, err := c.ListPendingDeletions(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading pending deletions: %w", err)
+	}
+
+	var p *model.PendingDeletion
+	for i := range pending {
+		if pending[i].ClusterID == clusterID {
+			p = &pending[i]
+			break
+		}
+	}
+
+	if p == nil {
+		return fmt.Errorf("cluster %d is not pending deletion", clusterID)
+	}
+
+	until, err := time.Parse(time.RFC3339, p.RecoverableUntil)
+	if err != nil {
+		return fmt.Errorf("invalid recovery deadline %q: %w", p.RecoverableUntil, err)
+	}
+
+	if time.Now().After(until) {
+		return fmt.Errorf("recovery window of cluster %d closed at %s", clusterID, p.RecoverableUntil)
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/recover", c.AccountID, clusterID)
+
+	return c.post(ctx, path, nil, nil)
+}
+
+func (c *Client) ListClusterRequest(ctx context.Context, clusterID int64, typ string) ([]model.ClusterRequest, error) {
+	var (
+		result []model.ClusterRequest
+		query  []string
+	)
+
+	if typ != "" {
+		query = append(query, "type", typ)
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/request", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result, query...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) GetClusterRequest(ctx context.Context, requestID int64) (model.ClusterRequest, error) {
+	var result model.ClusterRequest
+	path := fmt.Sprintf("/account/%d/cluster/request/%d", c.AccountID, requestID)
+	err := c.get(ctx, path, &result)
+	return result, err
+}
+
+// GetCreateRequest returns the parameters the cluster was originally created
+// with, as recorded by its create request. It returns ErrNoCreateRequest if
+// the record was not retained.
+func (c *Client) GetCreateRequest(ctx context.Context, clusterID int64) (*model.ClusterCreateRequest, error) {
+	reqs, err := c.ListClusterRequest(ctx, clusterID, "CREATE_CLUSTER")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range reqs {
+		if r.RequestBody == "" {
+			continue
+		}
+
+		var req model.ClusterCreateRequest
+		if err := json.Unmarshal([]byte(r.RequestBody), &req); err != nil {
+			return nil, fmt.Errorf("error decoding create request %d: %w", r.ID, err)
+		}
+
+		return &req, nil
+	}
+
+	return nil, fmt.Errorf("cluster %d: %w", clusterID, ErrNoCreateRequest)
+}
+
+func (c *Client) GetRequestEvents(ctx context.Context, requestID int64) ([]model.ClusterEvent, error) {
+	var result struct {
+		Events []model.ClusterEvent `json:"events"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/request/%d/events", c.AccountID, requestID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Events, nil
+}
+
+func (c *Client) ListAllowlistRules(ctx context.Context, clusterID int64) ([]model.AllowedIP, error) {
+	var result []model.AllowedIP
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/firewall/allowed", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetFirewallEvaluationOrder returns the firewall rules of the cluster in the
+// order they are evaluated, which helps to debug overlapping rules.
+func (c *Client) GetFirewallEvaluationOrder(ctx context.Context, clusterID int64) ([]model.FirewallRule, error) {
+	var result struct {
+		Rules []model.FirewallRule `json:"rules"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/firewall/evaluation-order", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result.Rules, func(i, j int) bool {
+		return result.Rules[i].Priority < result.Rules[j].Priority
+	})
+
+	return result.Rules, nil
+}
+
+// FindStaleAllowlistRules returns the allowlist rules of the cluster with no
+// traffic within the unusedSince period, including rules that were never hit.
+// Rules with no activity recorded are not reported. It returns an error
+// wrapping ErrNotSupported if the cluster does not record rule activity.
+func (c *Client) FindStaleAllowlistRules(ctx context.Context, clusterID int64, unusedSince time.Duration) ([]model.AllowedIP, error) {
+	rules, err := c.ListAllowlistRules(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Rules []struct {
+			ID      int64  `json:"id"`
+			LastHit string `json:"lastHit"`
+		} `json:"rules"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/firewall/activity", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		if IsNotFound(err) {
+			return nil, fmt.Errorf("allowlist rule activity: %w", ErrNotSupported)
+		}
+		return nil, err
+	}
+
+	lastHit := make(map[int64]string, len(result.Rules))
+	for _, r := range result.Rules {
+		lastHit[r.ID] = r.LastHit
+	}
+
+	cutoff := time.Now().Add(-unusedSince)
+
+	var stale []model.AllowedIP
+	for _, rule := range rules {
+		hit, ok := lastHit[rule.ID]
+		if !ok {
+			continue
+		}
+
+		if hit == "" {
+			stale = append(stale, rule)
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, hit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid last hit time of rule %d: %w", rule.ID, err)
+		}
+
+		if t.Before(cutoff) {
+			stale = append(stale, rule)
+		}
+	}
+
+	return stale, nil
+}
+
+// GetAllowlistRuleLimit returns the maximum number of allowlist rules of
+// the cluster, or zero if the API does not report it.
+func (c *Client) GetAllowlistRuleLimit(ctx context.Context, clusterID int64) (int, error) {
+	var result struct {
+		Limit int `json:"limit"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/firewall/limit", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		if IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return max(result.Limit, 0), nil
+}
+
+// checkAllowlistRuleLimit returns an error if the cluster would end up with
+// more allowlist rules than its limit. Nothing is checked if the limit is
+// not known.
+func (c *Client) checkAllowlistRuleLimit(ctx context.Context, clusterID int64, current, final int) error {
+	limit, err := c.GetAllowlistRuleLimit(ctx, clusterID)
+	if err != nil {
+		return fmt.Errorf("error reading allowlist rule limit: %w", err)
+	}
+
+	if limit > 0 && final > limit {
+		return fmt.Errorf("allowlist rule limit exceeded: cluster has %d rule(s), the change leaves %d which exceeds the limit of %d", current, final, limit)
+	}
+
+	return nil
+}
+
+// validateAllowlistAddress checks that the address is an IP address or
+// a CIDR block no wider than the /maxRange the cluster allows. A maxRange
+// of zero means no limit.
+func validateAllowlistAddress(address string, maxRange int64) error {
+	if _, err := netip.ParseAddr(address); err == nil {
+		return nil
+	}
+
+	p, err := netip.ParsePrefix(address)
+	if err != nil {
+		return fmt.Errorf("invalid allowed address %q, expected an IP address or a CIDR block", address)
+	}
+
+	if maxRange > 0 && int64(p.Bits()) < maxRange {
+		return fmt.Errorf("allowed address %q is wider than the cluster allows, use a prefix of /%d or longer", address, maxRange)
+	}
+
+	return nil
+}
+
+// CreateAllowlistRule allows traffic from the address, an IP address or
+// a CIDR block, to the cluster. CIDR blocks are checked against the
+// maximum range allowed by the cluster, and the new rule against the
+// cluster's rule limit, before the rule is created.
+func (c *Client) CreateAllowlistRule(ctx context.Context, clusterID int64, address string) ([]model.AllowedIP, error) {
+	var maxRange int64
+
+	if strings.Contains(address, "/") {
+		cluster, err := c.GetCluster(ctx, clusterID)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cluster: %w", err)
+		}
+
+		maxRange = cluster.MaxAllowedCIDRRange
+	}
+
+	if err := validateAllowlistAddress(address, maxRange); err != nil {
+		return nil, err
+	}
+
+	rules, err := c.ListAllowlistRules(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing allowlist rules: %w", err)
+	}
+
+	if err := c.checkAllowlistRuleLimit(ctx, clusterID, len(rules), len(rules)+1); err != nil {
+		return nil, err
+	}
+
+	return c.createAllowlistRule(ctx, clusterID, address)
+}
+
+func (c *Client) createAllowlistRule(ctx context.Context, clusterID int64, address string) ([]model.AllowedIP, error) {
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/firewall/allowed", c.AccountID, clusterID)
+
+	var result []model.AllowedIP
+
+	data := map[string]interface{}{
+		"ipAddress": address,
+	}
+
+	if err := c.post(ctx, path, data, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) DeleteAllowlistRule(ctx context.Context, clusterID, ruleID int64) error {
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/firewall/allowed/%d", c.AccountID, clusterID, ruleID)
+
+	return c.delete(ctx, path)
+}
+
+func (c *Client) ListDataCenters(ctx context.Context, clusterID int64) ([]model.Datacenter, error) {
+	var result model.Datacenters
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/dcs", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result, "enriched", "true"); err != nil {
+		return nil, err
+	}
+
+	return result.Datacenters, nil
+}
+
+// GetDCRegions maps names of the cluster datacenters to the full names
+// of their regions.
+func (c *Client) GetDCRegions(ctx context.Context, clusterID int64) (map[string]string, error) {
+	dcs, err := c.ListDataCenters(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		regions = make(map[string]string, len(dcs))
+		cache   = make(map[int64][]model.CloudProviderRegion) // provider ID -> regions
+	)
+
+	for _, dc := range dcs {
+		if dc.Region != nil && dc.Region.FullName != "" {
+			regions[dc.Name] = dc.Region.FullName
+			continue
+		}
+
+		providerRegions, ok := cache[dc.CloudProviderID]
+		if !ok {
+			r, err := c.ListCloudProviderRegions(ctx, dc.CloudProviderID)
+			if err != nil {
+				return nil, err
+			}
+			providerRegions = r.Regions
+			cache[dc.CloudProviderID] = providerRegions
+		}
+
+		i := slices.IndexFunc(providerRegions, func(r model.CloudProviderRegion) bool {
+			return r.ID == dc.RegionID
+		})
+		if i == -1 {
+			return nil, fmt.Errorf("unknown region %d of datacenter %q", dc.RegionID, dc.Name)
+		}
+
+		regions[dc.Name] = providerRegions[i].FullName
+	}
+
+	return regions, nil
+}
+
+// ChangeClusterCIDR changes the CIDR block of the cluster datacenter.
+// The cluster must be active, otherwise an error wrapping ErrClusterNotActive
+// is returned, and the new block must not overlap the CIDRs of the other
+// datacenters nor of the VPC peerings. It returns the ID of the request.
+func (c *Client) ChangeClusterCIDR(ctx context.Context, clusterID, dcID int64, newCIDR string) (int64, error) {
+	p, err := parseCIDRs([]string{newCIDR})
+	if err != nil {
+		return 0, err
+	}
+
+	cluster, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !strings.EqualFold(cluster.Status, "ACTIVE") {
+		return 0, fmt.Errorf("cluster %d is %s: %w", clusterID, cluster.Status, ErrClusterNotActive)
+	}
+
+	peerings, err := c.ListClusterVPCPeerings(ctx, clusterID)
+	if err != nil {
+		return 0, err
+	}
+
+	var taken []string
+	for _, dc := range cluster.Datacenters {
+		if dc.ID != dcID && dc.CIDRBlock != "" {
+			taken = append(taken, dc.CIDRBlock)
+		}
+	}
+	for _, peering := range peerings {
+		taken = append(taken, peering.CIDRList...)
+	}
+
+	prefixes, err := parseCIDRs(taken)
+	if err != nil {
+		return 0, err
+	}
+
+	if o, ok := firstOverlap(p[0], prefixes); ok {
+		return 0, fmt.Errorf("CIDR %s overlaps %s used by the cluster", newCIDR, o)
+	}
+
+	var result model.ClusterRequest
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/dc/%d/cidr", c.AccountID, clusterID, dcID)
+	data := map[string]interface{}{
+		"cidrBlock": newCIDR,
+	}
+
+	if err := c.post(ctx, path, data, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+// AddDataCenter adds a datacenter in another region to the cluster and
+// returns the ID of the request. The CIDR block of the new datacenter is
+// checked not to overlap those of the existing ones, as the API rejects
+// such a datacenter with an unclear error.
+func (c *Client) AddDataCenter(ctx context.Context, clusterID int64, req *model.DatacenterRequest) (int64, error) {
+	switch {
+	case req.RegionID == 0:
+		return 0, errors.New("region ID of the datacenter is required")
+	case req.InstanceID == 0:
+		return 0, errors.New("instance type ID of the datacenter is required")
+	case req.ReplicationFactor <= 0:
+		return 0, errors.New("replication factor of the datacenter must be positive")
+	}
+
+	dcs, err := c.ListDataCenters(ctx, clusterID)
+	if err != nil {
+		return 0, fmt.Errorf("error reading datacenters: %w", err)
+	}
+
+	var cidrs []string
+	for _, dc := range dcs {
+		if dc.RegionID == req.RegionID {
+			return 0, fmt.Errorf("cluster already has datacenter %q in region %d", dc.Name, req.RegionID)
+		}
+		if dc.CIDRBlock != "" {
+			cidrs = append(cidrs, dc.CIDRBlock)
+		}
+	}
+
+	if req.CIDRBlock != "" {
+		if err := ValidateMultiRegionCIDRs(append(cidrs, req.CIDRBlock)); err != nil {
+			return 0, fmt.Errorf("invalid CIDR block of the datacenter: %w", err)
+		}
+	}
+
+	var result model.ClusterRequest
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/dc", c.AccountID, clusterID)
+
+	if err := c.post(ctx, path, req, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+func (c *Client) RemoveDataCenter(ctx context.Context, clusterID, dcID int64) (int64, error) {
+	dcs, err := c.ListDataCenters(ctx, clusterID)
+	if err != nil {
+		return 0, fmt.Errorf("error reading datacenters: %w", err)
+	}
+
+	nodes, err := c.ListClusterNodes(ctx, clusterID)
+	if err != nil {
+		return 0, fmt.Errorf("error reading nodes: %w", err)
+	}
+
+	if err := validateDataCenterRemoval(dcs, nodes, dcID); err != nil {
+		return 0, err
+	}
+
+	var result model.ClusterRequest
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/dc/%d/delete", c.AccountID, clusterID, dcID)
+
+	if err := c.post(ctx, path, nil, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+// validateDataCenterRemoval ensures that after removing the dcID datacenter
+// the cluster is left with at least one datacenter, and that each of the
+// remaining ones has enough nodes to satisfy its replication factor.
+func validateDataCenterRemoval(dcs []model.Datacenter, nodes []model.Node, dcID int64) error {
+	var (
+		found     bool
+		remaining []model.Datacenter
+	)
+
+	for _, dc := range dcs {
+		if dc.ID == dcID {
+			found = true
+		} else {
+			remaining = append(remaining, dc)
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("datacenter %d not found in the cluster", dcID)
+	}
+
+	if len(remaining) == 0 {
+		return fmt.Errorf("unable to remove datacenter %d: it is the last datacenter of the cluster", dcID)
+	}
+
+	for _, dc := range remaining {
+		var n int64
+		for i := range nodes {
+			if nodes[i].DatacenterID == dc.ID && !strings.EqualFold(nodes[i].Status, "DELETED") {
+				n++
+			}
+		}
+
+		if n < dc.ReplicationFactor {
+			return fmt.Errorf("unable to remove datacenter %d: datacenter %q has %d node(s), fewer than its replication factor %d", dcID, dc.Name, n, dc.ReplicationFactor)
+		}
+	}
+
+	return nil
+}
+
+// ResizeCluster submits the resize of the cluster datacenters and returns
+// the cluster as reported right after, typically still resizing; use
+// WaitForClusterStatus to wait for it to become ACTIVE again. A resize that
+// would leave a datacenter with fewer nodes than its replication factor is
+// rejected before it is sent.
+func (c *Client) ResizeCluster(ctx context.Context, clusterID int64, req *model.ResizeRequest) (*model.Cluster, error) {
+	dcs, err := c.ListDataCenters(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading datacenters: %w", err)
+	}
+
+	if err := validateResize(dcs, req); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/resize", c.AccountID, clusterID)
+
+	if err := c.post(ctx, path, req, nil); err != nil {
+		return nil, err
+	}
+
+	return c.GetCluster(ctx, clusterID)
+}
+
+func validateResize(dcs []model.Datacenter, req *model.ResizeRequest) error {
+	if len(req.Datacenters) == 0 {
+		return errors.New("resize request does not change any datacenter")
+	}
+
+	for _, r := range req.Datacenters {
+		i := slices.IndexFunc(dcs, func(dc model.Datacenter) bool { return dc.ID == r.DatacenterID })
+		if i == -1 {
+			return fmt.Errorf("datacenter %d not found in the cluster", r.DatacenterID)
+		}
+
+		switch dc := &dcs[i]; {
+		case r.NumberOfNodes == 0 && r.InstanceID == 0:
+			return fmt.Errorf("resize of datacenter %q changes neither the node count nor the instance type", dc.Name)
+		case r.NumberOfNodes < 0:
+			return fmt.Errorf("invalid node count %d for datacenter %q", r.NumberOfNodes, dc.Name)
+		case r.NumberOfNodes != 0 && r.NumberOfNodes < dc.ReplicationFactor:
+			return fmt.Errorf("unable to resize datacenter %q to %d node(s), fewer than its replication factor %d", dc.Name, r.NumberOfNodes, dc.ReplicationFactor)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) ListClusterNodes(ctx context.Context, clusterID int64) ([]model.Node, error) {
+	var result model.Nodes
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/nodes", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result, "enriched", "true"); err != nil {
+		return nil, err
+	}
+
+	return result.Nodes, nil
+}
+
+// GetAZDistribution returns the number of active nodes in each availability
+// zone, grouped by datacenter name.
+func (c *Client) GetAZDistribution(ctx context.Context, clusterID int64) (map[string]map[string]int, error) {
+	dcs, err := c.ListDataCenters(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := c.ListClusterNodes(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(dcs))
+	dist := make(map[string]map[string]int, len(dcs))
+
+	for _, dc := range dcs {
+		names[dc.ID] = dc.Name
+		dist[dc.Name] = make(map[string]int)
+	}
+
+	for _, n := range model.NodesByStatus(nodes, "ACTIVE") {
+		name, ok := names[n.DatacenterID]
+		if !ok {
+			return nil, fmt.Errorf("node %d belongs to unknown datacenter %d", n.ID, n.DatacenterID)
+		}
+		dist[name][n.AvailabilityZone]++
+	}
+
+	return dist, nil
+}
+
+// IsMultiAZ reports whether nodes of a datacenter span more than one
+// availability zone.
+func IsMultiAZ(dist map[string]int) bool {
+	var zones int
+	for az, n := range dist {
+		if az != "" && n > 0 {
+			zones++
+		}
+	}
+	return zones > 1
+}
+
+// ListNodeReplacements returns the node replacement history of the cluster,
+// oldest first.
+func (c *Client) ListNodeReplacements(ctx context.Context, clusterID int64) ([]model.NodeReplacement, error) {
+	var result struct {
+		Replacements []model.NodeReplacement `json:"replacements"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/nodes/replacements", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	times := make(map[string]time.Time, len(result.Replacements))
+	for _, r := range result.Replacements {
+		t, err := time.Parse(time.RFC3339, r.ReplacedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replacement time of node %d: %w", r.NodeID, err)
+		}
+		times[r.ReplacedAt] = t
+	}
+
+	sort.SliceStable(result.Replacements, func(i, j int) bool {
+		return times[result.Replacements[i].ReplacedAt].Before(times[result.Replacements[j].ReplacedAt])
+	})
+
+	return result.Replacements, nil
+}
+
+// ListManagerTasks returns the Scylla Manager tasks, such as repairs and
+// backups, scheduled for the cluster.
+func (c *Client) ListManagerTasks(ctx context.Context, clusterID int64) ([]model.ManagerTask, error) {
+	var result struct {
+		Tasks []model.ManagerTask `json:"tasks"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/manager/tasks", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Tasks, nil
+}
+
+// TriggerManagerTask starts the given Scylla Manager task right away,
+// regardless of its schedule.
+func (c *Client) TriggerManagerTask(ctx context.Context, clusterID int64, taskID string) error {
+	path := fmt.Sprintf("/account/%d/cluster/%d/manager/tasks/%s/start", c.AccountID, clusterID, taskID)
+
+	return c.post(ctx, path, nil, nil)
+}
+
+// ListScalingEvents returns the resize history of the cluster, oldest first.
+func (c *Client) ListScalingEvents(ctx context.Context, clusterID int64) ([]model.ScalingEvent, error) {
+	var result struct {
+		Events []model.ScalingEvent `json:"events"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/scaling/events", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	times := make(map[string]time.Time, len(result.Events))
+	for _, e := range result.Events {
+		t, err := time.Parse(time.RFC3339, e.At)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scaling event time: %w", err)
+		}
+		times[e.At] = t
+	}
+
+	sort.SliceStable(result.Events, func(i, j int) bool {
+		return times[result.Events[i].At].Before(times[result.Events[j].At])
+	})
+
+	return result.Events, nil
+}
+
+func (c *Client) ListClusterVPCPeerings(ctx context.Context, clusterID int64) ([]model.VPCPeering, error) {
+	var result []model.VPCPeering
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/peer", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) CreateClusterVPCPeering(ctx context.Context, clusterID int64, req *model.VPCPeeringRequest) (*model.VPCPeering, error) {
+	var result struct {
+		ID         int64  `json:"id"`
+		ExternalID string `json:"externalId"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/peer", c.AccountID, clusterID)
+
+	if err := c.post(ctx, path, req, &result); err != nil {
+		return nil, err
+	}
+
+	return c.GetClusterVPCPeering(ctx, clusterID, result.ID)
+}
+
+// CheckPeeringDNSRequirements returns the DNS resolution prerequisites that
+// are not met for the VPC peering described by req. An empty result means
+// the peering can be created.
+func (c *Client) CheckPeeringDNSRequirements(ctx context.Context, clusterID int64, req *model.VPCPeeringRequest) ([]string, error) {
+	cluster, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmet []string
+
+	if !cluster.DNS {
+		unmet = append(unmet, "enable DNS association on the cluster")
+	}
+
+	if cluster.CloudProvider != nil && !strings.EqualFold(cluster.CloudProvider.Name, "AWS") {
+		return unmet, nil // DNS resolution options apply to AWS peerings only
+	}
+
+	var result struct {
+		Unmet []string `json:"unmet"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/peer/dns-check", c.AccountID, clusterID)
+
+	if err := c.post(ctx, path, req, &result); err != nil {
+		return nil, err
+	}
+
+	return append(unmet, result.Unmet...), nil
+}
+
+func (c *Client) GetClusterVPCPeering(ctx context.Context, clusterID, peerID int64) (*model.VPCPeering, error) {
+	var result model.VPCPeering
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/peer/%d", c.AccountID, clusterID, peerID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) DeleteClusterVPCPeering(ctx context.Context, clusterID, peerID int64) error {
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/peer/%d", c.AccountID, clusterID, peerID)
+
+	return c.delete(ctx, path)
+}
+
+func (c *Client) GetNetworkResources(ctx context.Context, clusterID int64) (*model.NetworkResources, error) {
+	var result model.NetworkResources
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/resources", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) CreateClusterConnection(ctx context.Context, clusterID int64, req *model.ClusterConnectionCreateRequest) (*model.ClusterConnection, error) {
+	var result struct {
+		ID           int64 `json:"id"`
+		ConnectionID int64 `json:"connectionID"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/connection", c.AccountID, clusterID)
+
+	if err := c.post(ctx, path, req, &result); err != nil {
+		return nil, err
+	}
+	return c.GetClusterConnection(ctx, clusterID, result.ConnectionID)
+}
+
+func (c *Client) GetClusterConnection(ctx context.Context, clusterID, connectionID int64) (*model.ClusterConnection, error) {
+	var result model.ClusterConnection
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/connection/%d", c.AccountID, clusterID, connectionID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) ListClusterConnections(ctx context.Context, clusterID int64) ([]model.ClusterConnection, error) {
+	result := struct {
+		Connections []model.ClusterConnection
+	}{}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/connection", c.AccountID, clusterID)
 
 	if err := c.get(ctx, path, &result); err != nil {
 		return nil, err
@@ -298,21 +1574,867 @@ func (c *Client) ListClusterConnections(ctx context.Context, clusterID int64) ([
 	return result.Connections, nil
 }
 
-func (c *Client) UpdateClusterConnections(ctx context.Context, clusterID, connectionID int64, req *model.ClusterConnectionUpdateRequest) error {
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/connection/%d", c.AccountID, clusterID, connectionID)
-	if err := c.patch(ctx, path, req, nil); err != nil {
-		return err
+func (c *Client) UpdateClusterConnections(ctx context.Context, clusterID, connectionID int64, req *model.ClusterConnectionUpdateRequest) error {
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/connection/%d", c.AccountID, clusterID, connectionID)
+	if err := c.patch(ctx, path, req, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteClusterConnection(ctx context.Context, clusterID, connectionID int64) error {
+	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/connection/%d", c.AccountID, clusterID, connectionID)
+
+	return c.delete(ctx, path)
+}
+
+func (c *Client) GetClusterTags(ctx context.Context, clusterID int64) (map[string]string, error) {
+	var result model.ClusterTags
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/tags", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Tags, nil
+}
+
+func (c *Client) SetClusterTags(ctx context.Context, clusterID int64, tags map[string]string) error {
+	path := fmt.Sprintf("/account/%d/cluster/%d/tags", c.AccountID, clusterID)
+
+	return c.post(ctx, path, &model.ClusterTags{Tags: tags}, nil)
+}
+
+func (c *Client) GetAccountDefaultTags(ctx context.Context) (map[string]string, error) {
+	var result model.ClusterTags
+
+	path := fmt.Sprintf("/account/%d/tags/default", c.AccountID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		if IsNotFound(err) {
+			return nil, nil // account has no default tags
+		}
+		return nil, err
+	}
+
+	return result.Tags, nil
+}
+
+// GetEffectiveTags returns the tags applied to the cluster: the default tags
+// of the account merged with the tags of the cluster, which take precedence.
+func (c *Client) GetEffectiveTags(ctx context.Context, clusterID int64) (map[string]model.EffectiveTag, error) {
+	accountTags, err := c.GetAccountDefaultTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterTags, err := c.GetClusterTags(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]model.EffectiveTag, len(accountTags)+len(clusterTags))
+	for k, v := range accountTags {
+		tags[k] = model.EffectiveTag{Value: v, Source: model.TagSourceAccount}
+	}
+	for k, v := range clusterTags {
+		tags[k] = model.EffectiveTag{Value: v, Source: model.TagSourceCluster}
+	}
+
+	return tags, nil
+}
+
+func (c *Client) requireMonitoring(ctx context.Context, clusterID int64) error {
+	cluster, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	if !cluster.PromProxyEnabled {
+		return fmt.Errorf("cluster %d: %w", clusterID, ErrMonitoringDisabled)
+	}
+
+	return nil
+}
+
+func (c *Client) GetHealthSignals(ctx context.Context, clusterID int64) (*model.HealthSignals, error) {
+	if err := c.requireMonitoring(ctx, clusterID); err != nil {
+		return nil, err
+	}
+
+	var result model.HealthSignals
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/health", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetPerfSnapshot returns the current throughput and p99 latencies of
+// the cluster.
+func (c *Client) GetPerfSnapshot(ctx context.Context, clusterID int64) (*model.PerfSnapshot, error) {
+	if err := c.requireMonitoring(ctx, clusterID); err != nil {
+		return nil, err
+	}
+
+	var result model.PerfSnapshot
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/performance", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) GetLoadSheddingState(ctx context.Context, clusterID int64) (*model.LoadShedding, error) {
+	if err := c.requireMonitoring(ctx, clusterID); err != nil {
+		return nil, err
+	}
+
+	var result model.LoadShedding
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/load-shedding", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetMonitoringVersion returns the version of the Scylla Monitoring stack
+// deployed for the cluster.
+func (c *Client) GetMonitoringVersion(ctx context.Context, clusterID int64) (string, error) {
+	var result struct {
+		Version string `json:"version"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/monitoring/version", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return "", err
+	}
+
+	return result.Version, nil
+}
+
+const (
+	minMonitoringRetention = 24 * time.Hour
+	maxMonitoringRetention = 365 * 24 * time.Hour
+)
+
+// GetMonitoringRetention returns how long the metrics of the cluster are kept.
+func (c *Client) GetMonitoringRetention(ctx context.Context, clusterID int64) (time.Duration, error) {
+	var result struct {
+		RetentionDays int64 `json:"retentionDays"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/retention", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return 0, err
+	}
+
+	return time.Duration(result.RetentionDays) * 24 * time.Hour, nil
+}
+
+// SetMonitoringRetention sets how long the metrics of the cluster are kept.
+// The retention must be a whole number of days, between 1 and 365.
+func (c *Client) SetMonitoringRetention(ctx context.Context, clusterID int64, d time.Duration) error {
+	if err := validateMonitoringRetention(d); err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"retentionDays": int64(d / (24 * time.Hour)),
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/retention", c.AccountID, clusterID)
+
+	return c.put(ctx, path, data, nil)
+}
+
+func validateMonitoringRetention(d time.Duration) error {
+	switch {
+	case d < minMonitoringRetention || d > maxMonitoringRetention:
+		return fmt.Errorf("invalid monitoring retention %s, expected between 1 and 365 days", d)
+	case d%(24*time.Hour) != 0:
+		return fmt.Errorf("invalid monitoring retention %s, expected a whole number of days", d)
+	}
+	return nil
+}
+
+func (c *Client) GetActiveConnections(ctx context.Context, clusterID int64) (int64, error) {
+	if err := c.requireMonitoring(ctx, clusterID); err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		ActiveConnections int64 `json:"activeConnections"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/connections", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ActiveConnections, nil
+}
+
+// replicationLagThreshold is the replication lag above which
+// GetReplicationLag logs a warning.
+const replicationLagThreshold = 30 * time.Second
+
+// GetReplicationLag returns the replication lag between datacenters of the
+// cluster, keyed by "<source>-><target>" datacenter names. The result is
+// empty for single datacenter clusters.
+func (c *Client) GetReplicationLag(ctx context.Context, clusterID int64) (map[string]time.Duration, error) {
+	if err := c.requireMonitoring(ctx, clusterID); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Lags []struct {
+			Source string `json:"source"`
+			Target string `json:"target"`
+			LagMs  int64  `json:"lagMs"`
+		} `json:"lags"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/replication-lag", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	lags := make(map[string]time.Duration, len(result.Lags))
+	for _, l := range result.Lags {
+		key := l.Source + "->" + l.Target
+		lags[key] = time.Duration(l.LagMs) * time.Millisecond
+
+		if lags[key] > replicationLagThreshold {
+			tflog.Warn(ctx, "replication lag exceeds threshold", map[string]interface{}{
+				"clusterId": clusterID,
+				"dcs":       key,
+				"lag":       lags[key].String(),
+				"threshold": replicationLagThreshold.String(),
+			})
+		}
+	}
+
+	return lags, nil
+}
+
+// GetDiskConfig returns the disk configuration of the cluster nodes,
+// keyed by datacenter name.
+func (c *Client) GetDiskConfig(ctx context.Context, clusterID int64) (map[string]model.DiskConfig, error) {
+	var result struct {
+		Disks []struct {
+			DataCenter string `json:"dataCenter"`
+			model.DiskConfig
+		} `json:"disks"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/disks", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	disks := make(map[string]model.DiskConfig, len(result.Disks))
+	for _, d := range result.Disks {
+		disks[d.DataCenter] = d.DiskConfig
+	}
+
+	return disks, nil
+}
+
+// GetAlertRouting returns the notification channels the alerts of each
+// severity are routed to.
+func (c *Client) GetAlertRouting(ctx context.Context, clusterID int64) ([]model.AlertRoute, error) {
+	var result struct {
+		Routes []model.AlertRoute `json:"routes"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/alerts/routing", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Routes, nil
+}
+
+func (c *Client) ListMetricsSinks(ctx context.Context, clusterID int64) ([]model.MetricsSink, error) {
+	var result struct {
+		Sinks []model.MetricsSink `json:"sinks"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/sinks", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Sinks, nil
+}
+
+func (c *Client) CreateMetricsSink(ctx context.Context, clusterID int64, sink *model.MetricsSink) (*model.MetricsSink, error) {
+	if err := sink.Validate(); err != nil {
+		return nil, err
+	}
+
+	var result model.MetricsSink
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/sinks", c.AccountID, clusterID)
+
+	if err := c.post(ctx, path, sink, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) DeleteMetricsSink(ctx context.Context, clusterID, sinkID int64) error {
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/sinks/%d", c.AccountID, clusterID, sinkID)
+
+	return c.delete(ctx, path)
+}
+
+func (c *Client) GetTLSConfig(ctx context.Context, clusterID int64) (*model.TLSConfig, error) {
+	var result model.TLSConfig
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/tls", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) RecommendedConsistency(ctx context.Context, clusterID int64) (read, write string, err error) {
+	cluster, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return "", "", err
+	}
+
+	rf := cluster.ReplicationFactor
+	if rf == 0 && cluster.Datacenter != nil {
+		rf = cluster.Datacenter.ReplicationFactor
+	}
+
+	read, write = recommendedConsistency(len(model.NodesByStatus(cluster.Nodes, "ACTIVE")), rf)
+
+	return read, write, nil
+}
+
+// recommendedConsistency returns the read and write consistency levels
+// recommended for a cluster with the given number of nodes and replication
+// factor:
+//
+//   - ONE when the cluster has a single node or keeps a single replica,
+//     as no quorum is possible;
+//   - LOCAL_QUORUM otherwise, which tolerates the loss of a replica and
+//     keeps requests within the coordinator's datacenter on multi-DC clusters.
+func recommendedConsistency(nodes int, rf int64) (read, write string) {
+	if nodes <= 1 || rf <= 1 {
+		return "ONE", "ONE"
+	}
+	return "LOCAL_QUORUM", "LOCAL_QUORUM"
+}
+
+func (c *Client) GetSLAStatus(ctx context.Context, clusterID int64) (*model.SLAStatus, error) {
+	var result model.SLAStatus
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/sla", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) GetAutoUpgrade(ctx context.Context, clusterID int64) (bool, error) {
+	var result struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/upgrade/auto", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return false, err
+	}
+
+	return result.Enabled, nil
+}
+
+func (c *Client) SetAutoUpgrade(ctx context.Context, clusterID int64, enabled bool) error {
+	path := fmt.Sprintf("/account/%d/cluster/%d/upgrade/auto", c.AccountID, clusterID)
+	data := map[string]interface{}{
+		"enabled": enabled,
+	}
+
+	return c.patch(ctx, path, data, nil)
+}
+
+func (c *Client) GetDefaultCompactionStrategy(ctx context.Context, clusterID int64) (string, error) {
+	var result struct {
+		DefaultStrategy string `json:"defaultStrategy"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/compaction", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		if IsNotFound(err) {
+			return "", fmt.Errorf("default compaction strategy: %w", ErrNotSupported)
+		}
+		return "", err
+	}
+
+	if result.DefaultStrategy == "" {
+		return "", fmt.Errorf("default compaction strategy: %w", ErrNotSupported)
+	}
+
+	return result.DefaultStrategy, nil
+}
+
+func (c *Client) ListKeyspaces(ctx context.Context, clusterID int64, includeSystem bool) ([]model.Keyspace, error) {
+	var result struct {
+		Keyspaces []model.Keyspace `json:"keyspaces"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/keyspaces", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	if includeSystem {
+		return result.Keyspaces, nil
+	}
+
+	var keyspaces []model.Keyspace
+	for i := range result.Keyspaces {
+		if !result.Keyspaces[i].IsSystem() {
+			keyspaces = append(keyspaces, result.Keyspaces[i])
+		}
+	}
+
+	return keyspaces, nil
+}
+
+func (c *Client) TriggerFailover(ctx context.Context, clusterID int64, dcName string) (int64, error) {
+	cluster, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return 0, err
+	}
+
+	if n := len(model.NodesByStatus(cluster.Nodes, "ACTIVE")); n <= 1 {
+		return 0, fmt.Errorf("unable to trigger failover: cluster %d has %d active node(s)", clusterID, n)
+	}
+
+	if clusterDatacenter(cluster, dcName) == nil {
+		return 0, fmt.Errorf("unable to trigger failover: datacenter %q not found in cluster %d", dcName, clusterID)
+	}
+
+	var result model.ClusterRequest
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/failover", c.AccountID, clusterID)
+	data := map[string]interface{}{
+		"dcName": dcName,
+	}
+
+	if err := c.post(ctx, path, data, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+func clusterDatacenter(cluster *model.Cluster, name string) *model.Datacenter {
+	for i := range cluster.Datacenters {
+		if strings.EqualFold(cluster.Datacenters[i].Name, name) {
+			return &cluster.Datacenters[i]
+		}
+	}
+
+	if cluster.Datacenter != nil && strings.EqualFold(cluster.Datacenter.Name, name) {
+		return cluster.Datacenter
 	}
 
 	return nil
 }
 
-func (c *Client) DeleteClusterConnection(ctx context.Context, clusterID, connectionID int64) error {
-	path := fmt.Sprintf("/account/%d/cluster/%d/network/vpc/connection/%d", c.AccountID, clusterID, connectionID)
+func (c *Client) GetQueryTimeouts(ctx context.Context, clusterID int64) (*model.QueryTimeouts, error) {
+	var result model.QueryTimeouts
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/timeouts", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) SetQueryTimeouts(ctx context.Context, clusterID int64, t model.QueryTimeouts) (int64, error) {
+	if err := t.Validate(); err != nil {
+		return 0, err
+	}
+
+	var result model.ClusterRequest
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/timeouts", c.AccountID, clusterID)
+
+	if err := c.post(ctx, path, &t, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+func (c *Client) GetPrometheusScrapeConfig(ctx context.Context, clusterID int64) (*model.ScrapeConfig, error) {
+	if err := c.requireMonitoring(ctx, clusterID); err != nil {
+		return nil, err
+	}
+
+	var result model.ScrapeConfig
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/metrics/scrape", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetConfigDiff returns the configuration parameters of the cluster whose
+// current value differs from the Scylla default, keyed by parameter name.
+func (c *Client) GetConfigDiff(ctx context.Context, clusterID int64) (map[string]model.ConfigValue, error) {
+	var result struct {
+		Parameters []model.ConfigParameter `json:"parameters"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/config", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]model.ConfigValue)
+	for _, p := range result.Parameters {
+		if p.Current != p.Default {
+			diff[p.Name] = p.ConfigValue
+		}
+	}
+
+	return diff, nil
+}
+
+func (c *Client) GetBackupStorage(ctx context.Context, clusterID int64) (*model.BackupStorage, error) {
+	var result model.BackupStorage
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/backup/storage", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) ListBackupDestinations(ctx context.Context, clusterID int64) ([]model.BackupDestination, error) {
+	var result struct {
+		Destinations []model.BackupDestination `json:"destinations"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/backup/destinations", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Destinations, nil
+}
+
+func (c *Client) SetBackupDestination(ctx context.Context, clusterID int64, d *model.BackupDestination) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/backup/destination", c.AccountID, clusterID)
+
+	return c.put(ctx, path, d, nil)
+}
+
+func (c *Client) GetSupportPlan(ctx context.Context, clusterID int64) (*model.SupportPlan, error) {
+	var result model.SupportPlan
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/support", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) ListRetentionPolicies(ctx context.Context) ([]model.RetentionPolicy, error) {
+	var result struct {
+		Policies []model.RetentionPolicy `json:"policies"`
+	}
+
+	path := fmt.Sprintf("/account/%d/backup/retention-policies", c.AccountID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Policies, nil
+}
+
+func (c *Client) GetBackupSchedule(ctx context.Context, clusterID int64) (*model.BackupSchedule, error) {
+	var result model.BackupSchedule
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/backup/schedule", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) SetBackupSchedule(ctx context.Context, clusterID int64, s *model.BackupSchedule) error {
+	policies, err := c.ListRetentionPolicies(ctx)
+	if err != nil && !IsNotFound(err) {
+		return fmt.Errorf("error reading retention policies: %w", err)
+	}
+
+	if err := validateRetention(policies, s.RetentionDays); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/backup/schedule", c.AccountID, clusterID)
+
+	return c.patch(ctx, path, s, nil)
+}
+
+// validateRetention checks that days matches one of the policies. Any
+// value is accepted when no policies are known.
+func validateRetention(policies []model.RetentionPolicy, days int) error {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	allowed := make([]string, 0, len(policies))
+
+	for _, p := range policies {
+		if p.RetentionDays == days {
+			return nil
+		}
+		allowed = append(allowed, strconv.Itoa(p.RetentionDays))
+	}
+
+	return fmt.Errorf("unsupported backup retention of %d day(s), allowed values: %s", days, strings.Join(allowed, ", "))
+}
+
+// GetCDCStatus reports whether Change Data Capture is enabled for the cluster.
+// It returns an error wrapping ErrNotSupported if the cluster's Scylla version
+// does not support CDC.
+func (c *Client) GetCDCStatus(ctx context.Context, clusterID int64) (bool, error) {
+	var result struct {
+		Supported bool `json:"supported"`
+		Enabled   bool `json:"enabled"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/cdc", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return false, err
+	}
+
+	if !result.Supported {
+		return false, fmt.Errorf("change data capture: %w", ErrNotSupported)
+	}
+
+	return result.Enabled, nil
+}
+
+func (c *Client) SetCDC(ctx context.Context, clusterID int64, enabled bool) (int64, error) {
+	if _, err := c.GetCDCStatus(ctx, clusterID); err != nil {
+		return 0, err
+	}
+
+	var result model.ClusterRequest
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/cdc", c.AccountID, clusterID)
+	data := map[string]interface{}{
+		"enabled": enabled,
+	}
+
+	if err := c.post(ctx, path, data, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+func (c *Client) ListResourceShares(ctx context.Context, clusterID int64) ([]model.ResourceShare, error) {
+	var result struct {
+		Shares []model.ResourceShare `json:"shares"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/shares", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Shares, nil
+}
+
+func (c *Client) CreateResourceShare(ctx context.Context, clusterID int64, share *model.ResourceShare) (*model.ResourceShare, error) {
+	if err := share.Validate(); err != nil {
+		return nil, err
+	}
+
+	var result model.ResourceShare
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/shares", c.AccountID, clusterID)
+
+	if err := c.post(ctx, path, share, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) DeleteResourceShare(ctx context.Context, clusterID, shareID int64) error {
+	path := fmt.Sprintf("/account/%d/cluster/%d/shares/%d", c.AccountID, clusterID, shareID)
+
+	return c.delete(ctx, path)
+}
+
+// ReserveCapacity reserves capacity for nodes additional nodes of the
+// cluster, to be available at the given time. It returns the reservation ID.
+func (c *Client) ReserveCapacity(ctx context.Context, clusterID, nodes int64, at time.Time) (int64, error) {
+	switch {
+	case nodes <= 0:
+		return 0, fmt.Errorf("number of nodes must be positive, got %d", nodes)
+	case !at.After(time.Now()):
+		return 0, fmt.Errorf("reservation time %s is not in the future", at.Format(time.RFC3339))
+	}
+
+	var result struct {
+		ID int64 `json:"id"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/capacity/reservation", c.AccountID, clusterID)
+	data := map[string]interface{}{
+		"nodes": nodes,
+		"at":    at.UTC().Format(time.RFC3339),
+	}
+
+	if err := c.post(ctx, path, data, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+func (c *Client) CancelReservation(ctx context.Context, reservationID int64) error {
+	path := fmt.Sprintf("/account/%d/capacity/reservation/%d", c.AccountID, reservationID)
 
 	return c.delete(ctx, path)
 }
 
+// GetBillingCurrency returns the billing currency of the account, or an
+// empty string if the API does not report it.
+func (c *Client) GetBillingCurrency(ctx context.Context) (string, error) {
+	var result struct {
+		Currency string `json:"currency"`
+	}
+
+	path := fmt.Sprintf("/account/%d/billing/currency", c.AccountID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		if IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return result.Currency, nil
+}
+
+func (c *Client) GetKeyRotationStatus(ctx context.Context, clusterID int64) (*model.KeyRotationStatus, error) {
+	var result model.KeyRotationStatus
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/encryption/rotation", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) RotateEncryptionKey(ctx context.Context, clusterID int64) (int64, error) {
+	var result model.ClusterRequest
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/encryption/rotate", c.AccountID, clusterID)
+
+	if err := c.post(ctx, path, nil, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+// GetInternodeEncryption reports whether the traffic between the nodes
+// of the cluster is encrypted with TLS.
+func (c *Client) GetInternodeEncryption(ctx context.Context, clusterID int64) (bool, error) {
+	var result struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	path := fmt.Sprintf("/account/%d/cluster/%d/encryption/internode", c.AccountID, clusterID)
+
+	if err := c.get(ctx, path, &result); err != nil {
+		return false, err
+	}
+
+	return result.Enabled, nil
+}
+
+// ListCostAnomalies returns the cost anomalies of the given account
+// detected since the given time.
+func (c *Client) ListCostAnomalies(ctx context.Context, accountID int64, since time.Time) ([]model.CostAnomaly, error) {
+	var result struct {
+		Anomalies []model.CostAnomaly `json:"anomalies"`
+	}
+
+	path := fmt.Sprintf("/account/%d/billing/anomalies", accountID)
+
+	if err := c.get(ctx, path, &result, "since", since.UTC().Format(time.RFC3339)); err != nil {
+		return nil, err
+	}
+
+	return result.Anomalies, nil
+}
+
 func fix_sf3112(c *model.ClusterConnectionInformation) {
 	for i := range c.Datacenters {
 		dc := &c.Datacenters[i]
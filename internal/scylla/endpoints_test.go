@@ -0,0 +1,2383 @@
+package scylla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+func TestListInstanceTypesByArch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /deployment/cloud-provider/1/region/2", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.CloudProviderInstances{
+			Instances: []model.CloudProviderInstance{
+				{ID: 1, ExternalID: "i4i.large", Architecture: "x86_64"},
+				{ID: 2, ExternalID: "i4g.large", Architecture: "arm64"},
+				{ID: 3, ExternalID: "i3.large"},
+			},
+		})
+	})
+
+	c := newTestClient(t, mux)
+
+	cases := map[string][]int64{
+		"x86": {1, 3},
+		"ARM": {2},
+	}
+
+	for arch, want := range cases {
+		t.Run(arch, func(t *testing.T) {
+			got, err := c.ListInstanceTypesByArch(context.Background(), 1, 2, arch)
+			if err != nil {
+				t.Fatalf("ListInstanceTypesByArch()=%+v", err)
+			}
+
+			if len(got) != len(want) {
+				t.Fatalf("want %d instances, got %+v", len(want), got)
+			}
+
+			for i := range got {
+				if got[i].ID != want[i] {
+					t.Fatalf("want instance %d, got %d", want[i], got[i].ID)
+				}
+			}
+		})
+	}
+
+	if _, err := c.ListInstanceTypesByArch(context.Background(), 1, 2, "sparc"); err == nil {
+		t.Fatal("want error for unsupported architecture, got nil")
+	}
+}
+
+func TestGetActiveConnections(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1, PromProxyEnabled: true}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 2}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/metrics/connections", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]int64{"activeConnections": 17})
+	})
+
+	c := newTestClient(t, mux)
+
+	n, err := c.GetActiveConnections(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetActiveConnections()=%+v", err)
+	}
+	if n != 17 {
+		t.Fatalf("want %d connections, got %d", 17, n)
+	}
+
+	if _, err := c.GetActiveConnections(context.Background(), 2); !errors.Is(err, ErrMonitoringDisabled) {
+		t.Fatalf("want ErrMonitoringDisabled, got %+v", err)
+	}
+}
+
+func TestRemoveDataCenter(t *testing.T) {
+	var removed bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/{id}/dcs", func(w http.ResponseWriter, r *http.Request) {
+		dcs := []model.Datacenter{{ID: 10, Name: "AWS_US_EAST_1", ReplicationFactor: 3}}
+		if r.PathValue("id") == "2" {
+			dcs = append(dcs, model.Datacenter{ID: 20, Name: "AWS_EU_WEST_1", ReplicationFactor: 3})
+		}
+		writeData(w, model.Datacenters{Datacenters: dcs})
+	})
+	mux.HandleFunc("GET /account/1/cluster/{id}/nodes", func(w http.ResponseWriter, r *http.Request) {
+		var nodes []model.Node
+		for i := int64(0); i < 6; i++ {
+			nodes = append(nodes, model.Node{ID: i, DatacenterID: 10 + 10*(i%2), Status: "ACTIVE"})
+		}
+		writeData(w, model.Nodes{Nodes: nodes})
+	})
+	mux.HandleFunc("POST /account/1/cluster/2/dc/20/delete", func(w http.ResponseWriter, r *http.Request) {
+		removed = true
+		writeData(w, model.ClusterRequest{ID: 99, Status: "QUEUED"})
+	})
+
+	c := newTestClient(t, mux)
+
+	if _, err := c.RemoveDataCenter(context.Background(), 1, 10); err == nil {
+		t.Fatal("want error removing the last datacenter, got nil")
+	}
+
+	id, err := c.RemoveDataCenter(context.Background(), 2, 20)
+	if err != nil {
+		t.Fatalf("RemoveDataCenter()=%+v", err)
+	}
+	if !removed || id != 99 {
+		t.Fatalf("want request %d, got %d (removed=%t)", 99, id, removed)
+	}
+}
+
+func TestGetTLSConfig(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/tls", serveTestdata(t, "tls_config.json"))
+
+	c := newTestClient(t, mux)
+
+	tls, err := c.GetTLSConfig(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetTLSConfig()=%+v", err)
+	}
+
+	want := model.TLSConfig{
+		MinVersion: "TLSv1.2",
+		Ciphers:    []string{"TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384"},
+		ClientAuth: true,
+	}
+	if !reflect.DeepEqual(*tls, want) {
+		t.Fatalf("want %+v, got %+v", want, *tls)
+	}
+}
+
+func TestGetInitialCredentials(t *testing.T) {
+	var fetched bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/credentials/initial", func(w http.ResponseWriter, r *http.Request) {
+		if fetched {
+			writeError(w, http.StatusGone, "Gone")
+			return
+		}
+		fetched = true
+		writeData(w, model.InitialCredentials{Username: "scylla", Password: "secret"})
+	})
+
+	c := newTestClient(t, mux)
+
+	creds, err := c.GetInitialCredentials(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetInitialCredentials()=%+v", err)
+	}
+	if creds.Username != "scylla" || creds.Password != "secret" {
+		t.Fatalf("unexpected credentials: %+v", creds)
+	}
+
+	if _, err := c.GetInitialCredentials(context.Background(), 1); !errors.Is(err, ErrCredentialAlreadyRetrieved) {
+		t.Fatalf("want ErrCredentialAlreadyRetrieved, got %+v", err)
+	}
+}
+
+func TestRecommendedConsistency(t *testing.T) {
+	nodes := func(n int) (nodes []model.Node) {
+		for i := 0; i < n; i++ {
+			nodes = append(nodes, model.Node{ID: int64(i), Status: "ACTIVE"})
+		}
+		return nodes
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{
+			ID:         1,
+			Nodes:      nodes(1),
+			Datacenter: &model.Datacenter{ReplicationFactor: 1},
+		}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{
+			ID:                2,
+			ReplicationFactor: 3,
+			Nodes:             nodes(6),
+			Datacenters:       []model.Datacenter{{ID: 1}, {ID: 2}},
+		}})
+	})
+
+	c := newTestClient(t, mux)
+
+	cases := map[int64]string{
+		1: "ONE",
+		2: "LOCAL_QUORUM",
+	}
+
+	for id, want := range cases {
+		read, write, err := c.RecommendedConsistency(context.Background(), id)
+		if err != nil {
+			t.Fatalf("RecommendedConsistency(%d)=%+v", id, err)
+		}
+		if read != want || write != want {
+			t.Fatalf("RecommendedConsistency(%d)=%q, %q, want %q", id, read, write, want)
+		}
+	}
+}
+
+func TestGetSLAStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/sla", serveTestdata(t, "sla_status.json"))
+	mux.HandleFunc("GET /account/1/cluster/2/sla", serveTestdata(t, "sla_status_strings.json"))
+
+	c := newTestClient(t, mux)
+
+	cases := map[int64]model.SLAStatus{
+		1: {UptimePercent: 99.95, PeriodStart: "2024-06-01T00:00:00Z", Breaches: 1},
+		2: {UptimePercent: 100, PeriodStart: "2024-06-01T00:00:00Z", Breaches: 0},
+	}
+
+	for id, want := range cases {
+		got, err := c.GetSLAStatus(context.Background(), id)
+		if err != nil {
+			t.Fatalf("GetSLAStatus(%d)=%+v", id, err)
+		}
+		if *got != want {
+			t.Fatalf("GetSLAStatus(%d)=%+v, want %+v", id, *got, want)
+		}
+	}
+}
+
+func TestCreateAllowlistRuleLimit(t *testing.T) {
+	rules := []model.AllowedIP{{ID: 1, Address: "10.0.0.1"}, {ID: 2, Address: "10.0.0.2"}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/{id}/network/firewall/allowed", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, rules)
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/network/firewall/limit", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]int{"limit": 2})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2/network/firewall/limit", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]int{"limit": 3})
+	})
+	mux.HandleFunc("POST /account/1/cluster/{id}/network/firewall/allowed", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, append(rules, model.AllowedIP{ID: 3, Address: "10.0.0.3"}))
+	})
+
+	c := newTestClient(t, mux)
+
+	_, err := c.CreateAllowlistRule(context.Background(), 1, "10.0.0.3")
+	if err == nil || !strings.Contains(err.Error(), "limit of 2") {
+		t.Fatalf("want limit exceeded error, got %+v", err)
+	}
+
+	got, err := c.CreateAllowlistRule(context.Background(), 2, "10.0.0.3")
+	if err != nil {
+		t.Fatalf("CreateAllowlistRule()=%+v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("want %d rules, got %+v", 3, got)
+	}
+}
+
+func TestCreateAllowlistRuleCIDRRange(t *testing.T) {
+	var created []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1, MaxAllowedCIDRRange: 16}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/network/firewall/allowed", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, []model.AllowedIP{})
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/network/firewall/allowed", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Address string `json:"ipAddress"`
+		}
+		readBody(t, r, &req)
+		created = append(created, req.Address)
+		writeData(w, []model.AllowedIP{{ID: 1, Address: req.Address}})
+	})
+
+	c := newTestClient(t, mux)
+
+	for _, addr := range []string{"0.0.0.0/0", "10.0.0.0/8", "10.0.0.256", "10.0.0.0/33"} {
+		if _, err := c.CreateAllowlistRule(context.Background(), 1, addr); err == nil {
+			t.Errorf("CreateAllowlistRule(%q): want error", addr)
+		}
+	}
+
+	for _, addr := range []string{"10.1.0.0/16", "10.2.3.0/24", "10.0.0.1"} {
+		if _, err := c.CreateAllowlistRule(context.Background(), 1, addr); err != nil {
+			t.Errorf("CreateAllowlistRule(%q)=%+v", addr, err)
+		}
+	}
+
+	if want := []string{"10.1.0.0/16", "10.2.3.0/24", "10.0.0.1"}; !reflect.DeepEqual(created, want) {
+		t.Fatalf("got created rules %q, want %q", created, want)
+	}
+}
+
+func TestGetAZDistribution(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/dcs", serveTestdata(t, "az_distribution_dcs.json"))
+	mux.HandleFunc("GET /account/1/cluster/1/nodes", serveTestdata(t, "az_distribution_nodes.json"))
+
+	c := newTestClient(t, mux)
+
+	dist, err := c.GetAZDistribution(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetAZDistribution()=%+v", err)
+	}
+
+	want := map[string]map[string]int{
+		"AWS_US_EAST_1": {"use1-az1": 1, "use1-az2": 1, "use1-az4": 1},
+		"AWS_EU_WEST_1": {"euw1-az1": 2},
+	}
+	if !reflect.DeepEqual(dist, want) {
+		t.Fatalf("want %+v, got %+v", want, dist)
+	}
+
+	if !IsMultiAZ(dist["AWS_US_EAST_1"]) {
+		t.Fatal("want AWS_US_EAST_1 to be multi-AZ")
+	}
+	if IsMultiAZ(dist["AWS_EU_WEST_1"]) {
+		t.Fatal("want AWS_EU_WEST_1 to be single-AZ")
+	}
+}
+
+func TestAutoUpgrade(t *testing.T) {
+	var enabled bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/upgrade/auto", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]bool{"enabled": enabled})
+	})
+	mux.HandleFunc("PATCH /account/1/cluster/1/upgrade/auto", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Enabled bool `json:"enabled"`
+		}
+		readBody(t, r, &req)
+		enabled = req.Enabled
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+
+	for _, want := range []bool{true, false} {
+		if err := c.SetAutoUpgrade(context.Background(), 1, want); err != nil {
+			t.Fatalf("SetAutoUpgrade()=%+v", err)
+		}
+
+		got, err := c.GetAutoUpgrade(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("GetAutoUpgrade()=%+v", err)
+		}
+		if got != want {
+			t.Fatalf("want auto upgrade %t, got %t", want, got)
+		}
+	}
+}
+
+func TestGetDefaultCompactionStrategy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/compaction", serveTestdata(t, "compaction_strategy.json"))
+	mux.HandleFunc("GET /account/1/cluster/2/compaction", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Not Found")
+	})
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetDefaultCompactionStrategy(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetDefaultCompactionStrategy()=%+v", err)
+	}
+	if want := "IncrementalCompactionStrategy"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+
+	if _, err := c.GetDefaultCompactionStrategy(context.Background(), 2); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("want ErrNotSupported, got %+v", err)
+	}
+}
+
+func TestCanDeployInRegion(t *testing.T) {
+	var created bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/deployment/cloud-provider/1/region/1/entitlement", serveFixture(`{
+		"data": {"entitled": true}
+	}`))
+	mux.HandleFunc("GET /account/1/deployment/cloud-provider/1/region/2/entitlement", serveFixture(`{
+		"data": {"entitled": false, "reason": "region requires an enterprise agreement"}
+	}`))
+	mux.HandleFunc("POST /account/1/cluster", func(w http.ResponseWriter, r *http.Request) {
+		created = true
+		writeError(w, http.StatusBadRequest, "Bad Request")
+	})
+
+	c := newTestClient(t, mux)
+
+	ok, _, err := c.CanDeployInRegion(context.Background(), 1, 1)
+	if err != nil || !ok {
+		t.Fatalf("CanDeployInRegion()=%t, %+v, want entitled", ok, err)
+	}
+
+	ok, reason, err := c.CanDeployInRegion(context.Background(), 1, 2)
+	if err != nil || ok || reason == "" {
+		t.Fatalf("CanDeployInRegion()=%t, %q, %+v, want gated with reason", ok, reason, err)
+	}
+
+	req := testCreateRequest("test")
+	req.RegionID = 2
+
+	_, err = c.CreateCluster(context.Background(), &req)
+	if err == nil || !strings.Contains(err.Error(), reason) {
+		t.Fatalf("want entitlement error, got %+v", err)
+	}
+	if created {
+		t.Fatal("want create request not to be sent for gated region")
+	}
+}
+
+func TestListKeyspaces(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/keyspaces", serveFixture(`{
+		"data": {
+			"keyspaces": [
+				{"name": "system", "replicationStrategy": "LocalStrategy", "tables": 30},
+				{"name": "system_auth", "replicationStrategy": "SimpleStrategy", "tables": 3},
+				{"name": "app", "replicationStrategy": "NetworkTopologyStrategy", "tables": 4}
+			]
+		}
+	}`))
+
+	c := newTestClient(t, mux)
+
+	user, err := c.ListKeyspaces(context.Background(), 1, false)
+	if err != nil {
+		t.Fatalf("ListKeyspaces()=%+v", err)
+	}
+	want := []model.Keyspace{{Name: "app", ReplicationStrategy: "NetworkTopologyStrategy", Tables: 4}}
+	if !reflect.DeepEqual(user, want) {
+		t.Fatalf("want %+v, got %+v", want, user)
+	}
+
+	all, err := c.ListKeyspaces(context.Background(), 1, true)
+	if err != nil {
+		t.Fatalf("ListKeyspaces()=%+v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("want %d keyspaces, got %+v", 3, all)
+	}
+}
+
+func TestTriggerFailover(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", serveFixture(`{
+		"data": {
+			"cluster": {
+				"id": 1,
+				"dc": {"id": 10, "Name": "AWS_US_EAST_1"},
+				"nodes": [{"id": 1, "status": "ACTIVE"}]
+			}
+		}
+	}`))
+	mux.HandleFunc("GET /account/1/cluster/2", serveFixture(`{
+		"data": {
+			"cluster": {
+				"id": 2,
+				"dataCenters": [{"id": 20, "Name": "AWS_US_EAST_1"}],
+				"nodes": [
+					{"id": 1, "status": "ACTIVE"},
+					{"id": 2, "status": "ACTIVE"},
+					{"id": 3, "status": "ACTIVE"}
+				]
+			}
+		}
+	}`))
+	mux.HandleFunc("POST /account/1/cluster/2/failover", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			DCName string `json:"dcName"`
+		}
+		readBody(t, r, &req)
+		if req.DCName != "AWS_US_EAST_1" {
+			t.Errorf("unexpected datacenter: %q", req.DCName)
+		}
+		writeData(w, model.ClusterRequest{ID: 5, Status: "QUEUED"})
+	})
+
+	c := newTestClient(t, mux)
+
+	if _, err := c.TriggerFailover(context.Background(), 1, "AWS_US_EAST_1"); err == nil {
+		t.Fatal("want error for single-node cluster, got nil")
+	}
+
+	if _, err := c.TriggerFailover(context.Background(), 2, "AWS_EU_WEST_1"); err == nil {
+		t.Fatal("want error for unknown datacenter, got nil")
+	}
+
+	id, err := c.TriggerFailover(context.Background(), 2, "AWS_US_EAST_1")
+	if err != nil {
+		t.Fatalf("TriggerFailover()=%+v", err)
+	}
+	if id != 5 {
+		t.Fatalf("want request %d, got %d", 5, id)
+	}
+}
+
+func TestQueryTimeouts(t *testing.T) {
+	var timeouts model.QueryTimeouts
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/timeouts", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, timeouts)
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/timeouts", func(w http.ResponseWriter, r *http.Request) {
+		readBody(t, r, &timeouts)
+		writeData(w, model.ClusterRequest{ID: 3, Status: "QUEUED"})
+	})
+
+	c := newTestClient(t, mux)
+
+	if _, err := c.SetQueryTimeouts(context.Background(), 1, model.QueryTimeouts{ReadTimeoutMs: 5000, WriteTimeoutMs: -1, RangeTimeoutMs: 10000}); err == nil {
+		t.Fatal("want error for negative write timeout, got nil")
+	}
+
+	want := model.QueryTimeouts{ReadTimeoutMs: 5000, WriteTimeoutMs: 2000, RangeTimeoutMs: 10000}
+
+	id, err := c.SetQueryTimeouts(context.Background(), 1, want)
+	if err != nil {
+		t.Fatalf("SetQueryTimeouts()=%+v", err)
+	}
+	if id != 3 {
+		t.Fatalf("want request %d, got %d", 3, id)
+	}
+
+	got, err := c.GetQueryTimeouts(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetQueryTimeouts()=%+v", err)
+	}
+	if *got != want {
+		t.Fatalf("want %+v, got %+v", want, *got)
+	}
+
+	read, write, rng := got.Durations()
+	if read != 5*time.Second || write != 2*time.Second || rng != 10*time.Second {
+		t.Fatalf("unexpected durations: read %s, write %s, range %s", read, write, rng)
+	}
+}
+
+func TestGetPrometheusScrapeConfig(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1, PromProxyEnabled: true}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 2}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/metrics/scrape", serveFixture(`{
+		"data": {
+			"url": "https://prom.example.com/federate",
+			"jobName": "scylla-cluster-1",
+			"bearerToken": "s3cr3t"
+		}
+	}`))
+
+	c := newTestClient(t, mux)
+
+	cfg, err := c.GetPrometheusScrapeConfig(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetPrometheusScrapeConfig()=%+v", err)
+	}
+	if cfg.BearerToken != "s3cr3t" || cfg.JobName != "scylla-cluster-1" {
+		t.Fatalf("unexpected scrape config: %#v", *cfg)
+	}
+	if s := fmt.Sprint(cfg); strings.Contains(s, "s3cr3t") {
+		t.Fatalf("want token masked, got %s", s)
+	}
+
+	if _, err := c.GetPrometheusScrapeConfig(context.Background(), 2); !errors.Is(err, ErrMonitoringDisabled) {
+		t.Fatalf("want ErrMonitoringDisabled, got %+v", err)
+	}
+}
+
+func TestCreateClusterMandatoryTags(t *testing.T) {
+	var created int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/clusters", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.Clusters{})
+	})
+	mux.HandleFunc("GET /account/1/tags/mandatory", serveFixture(`{
+		"data": {"keys": ["team", "cost-center"]}
+	}`))
+	mux.HandleFunc("POST /account/1/cluster", func(w http.ResponseWriter, r *http.Request) {
+		created++
+		writeData(w, map[string]int64{"requestId": 7})
+	})
+	mux.HandleFunc("GET /account/1/cluster/request/7", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterRequest{ID: 7, Status: "QUEUED"})
+	})
+
+	c := newTestClient(t, mux)
+
+	req := testCreateRequest("test")
+	req.Tags = map[string]string{"team": "db"}
+
+	_, err := c.CreateCluster(context.Background(), &req)
+	if err == nil || !strings.Contains(err.Error(), "cost-center") {
+		t.Fatalf("want missing tag error, got %+v", err)
+	}
+	if created != 0 {
+		t.Fatal("want create request not to be sent")
+	}
+
+	req.Tags["cost-center"] = "42"
+
+	r, err := c.CreateCluster(context.Background(), &req)
+	if err != nil {
+		t.Fatalf("CreateCluster()=%+v", err)
+	}
+	if r.ID != 7 || created != 1 {
+		t.Fatalf("want request %d created once, got %d (created %d times)", 7, r.ID, created)
+	}
+}
+
+func TestRecoverCluster(t *testing.T) {
+	var recovered []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/clusters/pending-deletion", func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now().UTC()
+		writeData(w, map[string]interface{}{
+			"clusters": []model.PendingDeletion{{
+				ClusterID:        1,
+				DeletedAt:        now.Add(-time.Hour).Format(time.RFC3339),
+				RecoverableUntil: now.Add(time.Hour).Format(time.RFC3339),
+			}, {
+				ClusterID:        2,
+				DeletedAt:        now.Add(-72 * time.Hour).Format(time.RFC3339),
+				RecoverableUntil: now.Add(-time.Hour).Format(time.RFC3339),
+			}},
+		})
+	})
+	mux.HandleFunc("POST /account/1/cluster/{id}/recover", func(w http.ResponseWriter, r *http.Request) {
+		recovered = append(recovered, r.PathValue("id"))
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+
+	pending, err := c.ListPendingDeletions(context.Background())
+	if err != nil {
+		t.Fatalf("ListPendingDeletions()=%+v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("want %d pending deletions, got %+v", 2, pending)
+	}
+
+	if err := c.RecoverCluster(context.Background(), 1); err != nil {
+		t.Fatalf("RecoverCluster()=%+v", err)
+	}
+
+	if err := c.RecoverCluster(context.Background(), 2); err == nil {
+		t.Fatal("want error after recovery window, got nil")
+	}
+
+	if err := c.RecoverCluster(context.Background(), 3); err == nil {
+		t.Fatal("want error for cluster not pending deletion, got nil")
+	}
+
+	if !reflect.DeepEqual(recovered, []string{"1"}) {
+		t.Fatalf("want only cluster 1 recovered, got %v", recovered)
+	}
+}
+
+func TestGetConfigDiff(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/config", serveTestdata(t, "config_parameters.json"))
+
+	c := newTestClient(t, mux)
+
+	diff, err := c.GetConfigDiff(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetConfigDiff()=%+v", err)
+	}
+
+	want := map[string]model.ConfigValue{
+		"compaction_throughput_mb_per_sec": {Default: "0", Current: "64"},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Fatalf("want %+v, got %+v", want, diff)
+	}
+}
+
+func TestCheckPeeringDNSRequirements(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1, DNS: true, CloudProvider: &model.CloudProvider{Name: "AWS"}}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 2, CloudProvider: &model.CloudProvider{Name: "AWS"}}})
+	})
+	mux.HandleFunc("POST /account/1/cluster/{id}/network/vpc/peer/dns-check", func(w http.ResponseWriter, r *http.Request) {
+		var req model.VPCPeeringRequest
+		readBody(t, r, &req)
+
+		var unmet []string
+		if req.VPC == "vpc-nodns" {
+			unmet = append(unmet, "enable DNS resolution on accepter VPC")
+		}
+		writeData(w, map[string][]string{"unmet": unmet})
+	})
+
+	c := newTestClient(t, mux)
+
+	unmet, err := c.CheckPeeringDNSRequirements(context.Background(), 1, &model.VPCPeeringRequest{VPC: "vpc-1"})
+	if err != nil {
+		t.Fatalf("CheckPeeringDNSRequirements()=%+v", err)
+	}
+	if len(unmet) != 0 {
+		t.Fatalf("want no unmet prerequisites, got %v", unmet)
+	}
+
+	unmet, err = c.CheckPeeringDNSRequirements(context.Background(), 2, &model.VPCPeeringRequest{VPC: "vpc-nodns"})
+	if err != nil {
+		t.Fatalf("CheckPeeringDNSRequirements()=%+v", err)
+	}
+	want := []string{
+		"enable DNS association on the cluster",
+		"enable DNS resolution on accepter VPC",
+	}
+	if !reflect.DeepEqual(unmet, want) {
+		t.Fatalf("want %v, got %v", want, unmet)
+	}
+}
+
+func TestGetRequestEvents(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/request/7/events", serveTestdata(t, "request_events.json"))
+
+	c := newTestClient(t, mux)
+
+	events, err := c.GetRequestEvents(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetRequestEvents()=%+v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("want %d events, got %+v", 2, events)
+	}
+
+	want := model.ClusterEvent{
+		ID:        2,
+		RequestID: 7,
+		Timestamp: "2024-06-01T10:05:00Z",
+		Level:     "ERROR",
+		Message:   "Insufficient capacity for i4i.large in us-east-1a",
+	}
+	if events[1] != want {
+		t.Fatalf("want %+v, got %+v", want, events[1])
+	}
+}
+
+func TestGetBackupStorage(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/backup/storage", serveTestdata(t, "backup_storage.json"))
+	mux.HandleFunc("GET /account/1/cluster/2/backup/storage", serveTestdata(t, "backup_storage_fractional.json"))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetBackupStorage(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetBackupStorage()=%+v", err)
+	}
+
+	want := model.BackupStorage{
+		Location:    "s3://scylla-cloud-backup-1/cluster-1",
+		SizeBytes:   1 << 40,
+		ObjectCount: 20480,
+	}
+	if *got != want {
+		t.Fatalf("want %+v, got %+v", want, *got)
+	}
+
+	if _, err := c.GetBackupStorage(context.Background(), 2); err == nil {
+		t.Fatal("want error for fractional size, got nil")
+	}
+}
+
+func TestIsClusterNameAvailable(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/clusters", serveFixture(`{
+		"data": {
+			"clusters": [
+				{"id": 1, "clusterName": "orders", "status": "ACTIVE"},
+				{"id": 2, "clusterName": "legacy", "status": "DELETED"}
+			]
+		}
+	}`))
+	mux.HandleFunc("GET /account/1/clusters/pending-deletion", serveFixture(`{
+		"data": {
+			"clusters": [
+				{"clusterId": 3, "clusterName": "sessions", "recoverableUntil": "2024-06-08T00:00:00Z"}
+			]
+		}
+	}`))
+
+	c := newTestClient(t, mux)
+
+	cases := map[string]bool{
+		"payments": true,
+		"legacy":   true,
+		"orders":   false,
+		"sessions": false,
+	}
+
+	for name, want := range cases {
+		got, err := c.IsClusterNameAvailable(context.Background(), name)
+		if err != nil {
+			t.Fatalf("IsClusterNameAvailable(%q)=%+v", name, err)
+		}
+		if got != want {
+			t.Fatalf("IsClusterNameAvailable(%q)=%t, want %t", name, got, want)
+		}
+	}
+
+	req := testCreateRequest("orders")
+
+	_, err := c.CreateCluster(context.Background(), &req)
+	if err == nil || !strings.Contains(err.Error(), "already taken") {
+		t.Fatalf("want name taken error, got %+v", err)
+	}
+}
+
+func TestCreateClusterChecksUnavailable(t *testing.T) {
+	var created int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/deployment/cloud-provider/1/region/1/entitlement", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusForbidden, "Forbidden")
+	})
+	mux.HandleFunc("GET /account/1/clusters", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusInternalServerError, "Internal Server Error")
+	})
+	mux.HandleFunc("GET /account/1/tags/mandatory", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusForbidden, "Forbidden")
+	})
+	mux.HandleFunc("POST /account/1/cluster", func(w http.ResponseWriter, r *http.Request) {
+		created++
+		writeData(w, map[string]int64{"requestId": 7})
+	})
+	mux.HandleFunc("GET /account/1/cluster/request/7", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterRequest{ID: 7, Status: "QUEUED"})
+	})
+
+	c := newTestClient(t, mux)
+
+	req := testCreateRequest("test")
+
+	if _, err := c.CreateCluster(context.Background(), &req); err != nil {
+		t.Fatalf("CreateCluster()=%+v", err)
+	}
+	if created != 1 {
+		t.Fatalf("want create request sent once, got %d", created)
+	}
+}
+
+func TestGetSupportPlan(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/support", serveTestdata(t, "support_plan.json"))
+
+	c := newTestClient(t, mux)
+
+	plan, err := c.GetSupportPlan(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetSupportPlan()=%+v", err)
+	}
+
+	want := model.SupportPlan{
+		Tier:            "Premium",
+		ResponseTimeSLA: "1h",
+		ContactChannels: []string{"email", "phone", "slack"},
+	}
+	if !reflect.DeepEqual(*plan, want) {
+		t.Fatalf("want %+v, got %+v", want, *plan)
+	}
+}
+
+func TestSetBackupSchedule(t *testing.T) {
+	var schedule model.BackupSchedule
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/backup/retention-policies", serveFixture(`{
+		"data": {
+			"policies": [
+				{"name": "standard", "retentionDays": 7},
+				{"name": "extended", "retentionDays": 30}
+			]
+		}
+	}`))
+	mux.HandleFunc("PATCH /account/1/cluster/1/backup/schedule", func(w http.ResponseWriter, r *http.Request) {
+		readBody(t, r, &schedule)
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+
+	policies, err := c.ListRetentionPolicies(context.Background())
+	if err != nil {
+		t.Fatalf("ListRetentionPolicies()=%+v", err)
+	}
+	if len(policies) != 2 {
+		t.Fatalf("want %d policies, got %+v", 2, policies)
+	}
+
+	err = c.SetBackupSchedule(context.Background(), 1, &model.BackupSchedule{Interval: "24h", RetentionDays: 14})
+	if err == nil || !strings.Contains(err.Error(), "7, 30") {
+		t.Fatalf("want unsupported retention error, got %+v", err)
+	}
+
+	want := model.BackupSchedule{Interval: "24h", RetentionDays: 30}
+	if err := c.SetBackupSchedule(context.Background(), 1, &want); err != nil {
+		t.Fatalf("SetBackupSchedule()=%+v", err)
+	}
+	if schedule != want {
+		t.Fatalf("want %+v, got %+v", want, schedule)
+	}
+}
+
+func TestSetBackupScheduleNoPolicies(t *testing.T) {
+	var schedule model.BackupSchedule
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/backup/retention-policies", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "040001")
+	})
+	mux.HandleFunc("GET /account/2/backup/retention-policies", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]interface{}{"policies": []model.RetentionPolicy{}})
+	})
+	mux.HandleFunc("PATCH /account/{account}/cluster/1/backup/schedule", func(w http.ResponseWriter, r *http.Request) {
+		readBody(t, r, &schedule)
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+
+	for _, account := range []int64{1, 2} {
+		c.AccountID = account
+		schedule = model.BackupSchedule{}
+
+		want := model.BackupSchedule{Interval: "24h", RetentionDays: 14}
+		if err := c.SetBackupSchedule(context.Background(), 1, &want); err != nil {
+			t.Fatalf("account %d: SetBackupSchedule()=%+v", account, err)
+		}
+		if schedule != want {
+			t.Fatalf("account %d: want %+v, got %+v", account, want, schedule)
+		}
+	}
+}
+
+func TestGetHealthSignals(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1, PromProxyEnabled: true}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 2}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/metrics/health", serveTestdata(t, "health_signals.json"))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetHealthSignals(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetHealthSignals()=%+v", err)
+	}
+
+	want := model.HealthSignals{CompactionBacklog: 12, PendingRepairs: 1}
+	if *got != want {
+		t.Fatalf("want %+v, got %+v", want, *got)
+	}
+
+	if _, err := c.GetHealthSignals(context.Background(), 2); !errors.Is(err, ErrMonitoringDisabled) {
+		t.Fatalf("want ErrMonitoringDisabled, got %+v", err)
+	}
+}
+
+func TestCDC(t *testing.T) {
+	var (
+		enabled   bool
+		requested bool
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/cdc", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]bool{"supported": true, "enabled": enabled})
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/cdc", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Enabled bool `json:"enabled"`
+		}
+		readBody(t, r, &req)
+		enabled = req.Enabled
+		writeData(w, map[string]int64{"id": 42})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2/cdc", serveFixture(`{
+		"data": {"supported": false, "enabled": false}
+	}`))
+	mux.HandleFunc("POST /account/1/cluster/2/cdc", func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		writeError(w, http.StatusBadRequest, "Bad Request")
+	})
+
+	c := newTestClient(t, mux)
+
+	id, err := c.SetCDC(context.Background(), 1, true)
+	if err != nil {
+		t.Fatalf("SetCDC()=%+v", err)
+	}
+	if id != 42 {
+		t.Fatalf("want request ID 42, got %d", id)
+	}
+
+	got, err := c.GetCDCStatus(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetCDCStatus()=%+v", err)
+	}
+	if !got {
+		t.Fatal("want CDC enabled")
+	}
+
+	if _, err := c.GetCDCStatus(context.Background(), 2); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("want ErrNotSupported, got %+v", err)
+	}
+	if _, err := c.SetCDC(context.Background(), 2, true); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("want ErrNotSupported, got %+v", err)
+	}
+	if requested {
+		t.Fatal("want no CDC request for an unsupported version")
+	}
+}
+
+func TestResourceShares(t *testing.T) {
+	var shares []model.ResourceShare
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/shares", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]interface{}{"shares": shares})
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/shares", func(w http.ResponseWriter, r *http.Request) {
+		var req model.ResourceShare
+		readBody(t, r, &req)
+		req.ID = int64(len(shares) + 1)
+		shares = append(shares, req)
+		writeData(w, req)
+	})
+
+	c := newTestClient(t, mux)
+
+	share, err := c.CreateResourceShare(context.Background(), 1, &model.ResourceShare{
+		TargetAccountID: 7,
+		Permission:      model.SharePermissionRead,
+	})
+	if err != nil {
+		t.Fatalf("CreateResourceShare()=%+v", err)
+	}
+	if share.ID != 1 {
+		t.Fatalf("want share ID 1, got %d", share.ID)
+	}
+
+	got, err := c.ListResourceShares(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListResourceShares()=%+v", err)
+	}
+	if !reflect.DeepEqual(got, []model.ResourceShare{*share}) {
+		t.Fatalf("want %+v, got %+v", []model.ResourceShare{*share}, got)
+	}
+
+	_, err = c.CreateResourceShare(context.Background(), 1, &model.ResourceShare{
+		TargetAccountID: 7,
+		Permission:      "OWNER",
+	})
+	if err == nil {
+		t.Fatal("want error for invalid permission, got nil")
+	}
+	if len(shares) != 1 {
+		t.Fatalf("want no share created for invalid permission, got %d shares", len(shares))
+	}
+}
+
+func TestGetFirewallEvaluationOrder(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/network/firewall/evaluation-order", serveTestdata(t, "firewall_evaluation_order.json"))
+
+	c := newTestClient(t, mux)
+
+	rules, err := c.GetFirewallEvaluationOrder(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetFirewallEvaluationOrder()=%+v", err)
+	}
+
+	var got []string
+	for _, r := range rules {
+		got = append(got, r.Address)
+	}
+
+	if want := []string{"10.0.1.0/24", "10.0.0.0/16", "0.0.0.0/0"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestGetConnectionsBatch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/connect", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("clusterId") == "2" {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		writeData(w, map[string]interface{}{
+			"broadcastType": "PUBLIC",
+			"credentials":   map[string]string{"username": "scylla", "password": "secret"},
+		})
+	})
+
+	c := newTestClient(t, mux)
+
+	conns, err := c.GetConnectionsBatch(context.Background(), []int64{1, 2, 3})
+
+	if len(conns) != 2 || conns[1] == nil || conns[3] == nil {
+		t.Fatalf("want connections for clusters 1 and 3, got %+v", conns)
+	}
+	if conns[1].Credentials.Username != "scylla" {
+		t.Fatalf("want username scylla, got %q", conns[1].Credentials.Username)
+	}
+	if !IsNotFound(err) || !strings.HasPrefix(err.Error(), "cluster 2: ") || strings.Contains(err.Error(), "\n") {
+		t.Fatalf("want not found error for cluster 2 only, got %+v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	conns, err = c.GetConnectionsBatch(ctx, []int64{1, 3})
+	if !errors.Is(err, context.Canceled) || len(conns) != 0 {
+		t.Fatalf("want context.Canceled and no connections, got %+v, %+v", conns, err)
+	}
+}
+
+func TestGetReplicationLag(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{PromProxyEnabled: true}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/metrics/replication-lag", serveTestdata(t, "replication_lag.json"))
+	mux.HandleFunc("GET /account/1/cluster/2/metrics/replication-lag", serveTestdata(t, "replication_lag_empty.json"))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetReplicationLag(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetReplicationLag()=%+v", err)
+	}
+
+	want := map[string]time.Duration{
+		"AWS_US_EAST_1->AWS_EU_WEST_1": 250 * time.Millisecond,
+		"AWS_EU_WEST_1->AWS_US_EAST_1": 45 * time.Second,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	got, err = c.GetReplicationLag(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetReplicationLag()=%+v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("want no lag for single datacenter cluster, got %v", got)
+	}
+}
+
+func TestReserveCapacity(t *testing.T) {
+	reservations := make(map[string]bool)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /account/1/cluster/1/capacity/reservation", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Nodes int64  `json:"nodes"`
+			At    string `json:"at"`
+		}
+		readBody(t, r, &req)
+		if req.Nodes != 3 {
+			t.Errorf("want 3 nodes, got %d", req.Nodes)
+		}
+		reservations["7"] = true
+		writeData(w, map[string]int64{"id": 7})
+	})
+	mux.HandleFunc("DELETE /account/1/capacity/reservation/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if !reservations[r.PathValue("id")] {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		delete(reservations, r.PathValue("id"))
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+
+	if _, err := c.ReserveCapacity(context.Background(), 1, 3, time.Now().Add(-time.Hour)); err == nil {
+		t.Fatal("want error for reservation in the past, got nil")
+	}
+	if len(reservations) != 0 {
+		t.Fatalf("want no reservation made, got %v", reservations)
+	}
+
+	id, err := c.ReserveCapacity(context.Background(), 1, 3, time.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("ReserveCapacity()=%+v", err)
+	}
+	if id != 7 {
+		t.Fatalf("want reservation ID 7, got %d", id)
+	}
+
+	if err := c.CancelReservation(context.Background(), id); err != nil {
+		t.Fatalf("CancelReservation()=%+v", err)
+	}
+	if len(reservations) != 0 {
+		t.Fatalf("want reservation cancelled, got %v", reservations)
+	}
+}
+
+func TestGetNetworkResources(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/network/resources", serveTestdata(t, "network_resources.json"))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetNetworkResources(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetNetworkResources()=%+v", err)
+	}
+
+	want := &model.NetworkResources{
+		VPCID:         "vpc-0a1b2c3d4e5f67890",
+		SubnetIDs:     []string{"subnet-0123456789abcdef0", "subnet-0fedcba9876543210"},
+		RouteTableIDs: []string{"rtb-0a1b2c3d4e5f67890"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestResolveInstanceTypeAlias(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /deployment/cloud-provider/1/region/1", serveFixture(`{
+		"data": {
+			"instances": [
+				{"id": 1, "externalId": "i3en.large"},
+				{"id": 2, "externalId": "i3en.xlarge"},
+				{"id": 3, "externalId": "i4i.large"},
+				{"id": 4, "externalId": "i4i.xlarge"}
+			]
+		}
+	}`))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.ResolveInstanceTypeAlias(context.Background(), 1, 1, "i3.large")
+	if err != nil {
+		t.Fatalf("ResolveInstanceTypeAlias()=%+v", err)
+	}
+	if got.ID != 1 {
+		t.Fatalf("want legacy i3.large to resolve to i3en.large, got %q", got.ExternalID)
+	}
+
+	got, err = c.ResolveInstanceTypeAlias(context.Background(), 1, 1, "I4I.LARGE")
+	if err != nil {
+		t.Fatalf("ResolveInstanceTypeAlias()=%+v", err)
+	}
+	if got.ID != 3 {
+		t.Fatalf("want i4i.large, got %q", got.ExternalID)
+	}
+
+	_, err = c.ResolveInstanceTypeAlias(context.Background(), 1, 1, "i4i.4xlarge")
+	if err == nil {
+		t.Fatal("want error for unknown instance type, got nil")
+	}
+	if !strings.Contains(err.Error(), "i4i.large, i4i.xlarge") {
+		t.Fatalf("want i4i suggestions, got %q", err)
+	}
+}
+
+func TestCreateClusterFromTemplate(t *testing.T) {
+	var created model.ClusterCreateRequest
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/clusters", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.Clusters{})
+	})
+	mux.HandleFunc("GET /account/1/cluster/templates", serveFixture(`{
+		"data": {
+			"templates": [{
+				"id": 3,
+				"name": "small",
+				"spec": {
+					"cloudProviderId": 1,
+					"regionId": 1,
+					"instanceId": 10,
+					"numberOfNodes": 3,
+					"replicationFactor": 3,
+					"broadcastType": "PUBLIC",
+					"tags": {"team": "db", "env": "dev"}
+				}
+			}]
+		}
+	}`))
+	mux.HandleFunc("POST /account/1/cluster", func(w http.ResponseWriter, r *http.Request) {
+		readBody(t, r, &created)
+		writeData(w, map[string]int64{"requestId": 7})
+	})
+	mux.HandleFunc("GET /account/1/cluster/request/7", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterRequest{ID: 7, Status: "QUEUED"})
+	})
+
+	c := newTestClient(t, mux)
+
+	templates, err := c.ListClusterTemplates(context.Background())
+	if err != nil {
+		t.Fatalf("ListClusterTemplates()=%+v", err)
+	}
+	if len(templates) != 1 || templates[0].Name != "small" {
+		t.Fatalf("want template small, got %+v", templates)
+	}
+
+	id, err := c.CreateClusterFromTemplate(context.Background(), 3, &model.ClusterCreateRequest{
+		ClusterName:   "orders",
+		NumberOfNodes: 6,
+		Tags:          map[string]string{"env": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("CreateClusterFromTemplate()=%+v", err)
+	}
+	if id != 7 {
+		t.Fatalf("want request ID 7, got %d", id)
+	}
+
+	want := model.ClusterCreateRequest{
+		CloudProviderID:   1,
+		RegionID:          1,
+		InstanceID:        10,
+		ClusterName:       "orders",
+		NumberOfNodes:     6,
+		ReplicationFactor: 3,
+		BroadcastType:     "PUBLIC",
+		Tags:              map[string]string{"team": "db", "env": "prod"},
+	}
+	if !reflect.DeepEqual(created, want) {
+		t.Fatalf("want %+v, got %+v", want, created)
+	}
+
+	if _, err := c.CreateClusterFromTemplate(context.Background(), 4, &model.ClusterCreateRequest{}); err == nil {
+		t.Fatal("want error for unknown template, got nil")
+	}
+}
+
+func TestGetAlertRouting(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/alerts/routing", serveTestdata(t, "alert_routing.json"))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetAlertRouting(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetAlertRouting()=%+v", err)
+	}
+
+	want := []model.AlertRoute{
+		{Severity: "CRITICAL", Channels: []string{"pagerduty", "email"}},
+		{Severity: "WARNING", Channels: []string{"email"}},
+		{Severity: "INFO", Channels: []string{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestFindStaleAllowlistRules(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/{id}/network/firewall/allowed", serveTestdata(t, "allowlist_rules.json"))
+	mux.HandleFunc("GET /account/1/cluster/1/network/firewall/activity", serveTestdata(t, "firewall_activity.json"))
+
+	c := newTestClient(t, mux)
+
+	rules, err := c.FindStaleAllowlistRules(context.Background(), 1, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("FindStaleAllowlistRules()=%+v", err)
+	}
+
+	var got []int64
+	for _, r := range rules {
+		got = append(got, r.ID)
+	}
+
+	if want := []int64{1, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want stale rules %v, got %v", want, got)
+	}
+
+	if _, err := c.FindStaleAllowlistRules(context.Background(), 2, time.Hour); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("want ErrNotSupported, got %+v", err)
+	}
+}
+
+func TestListNodeReplacements(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/nodes/replacements", serveTestdata(t, "node_replacements.json"))
+
+	c := newTestClient(t, mux)
+
+	replacements, err := c.ListNodeReplacements(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListNodeReplacements()=%+v", err)
+	}
+
+	var got []int64
+	for _, r := range replacements {
+		got = append(got, r.NodeID)
+	}
+
+	if want := []int64{10, 11, 12}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want nodes %v, got %v", want, got)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	var rotating bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/encryption/rotation", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.KeyRotationStatus{
+			LastRotated:   "2024-01-01T00:00:00Z",
+			NextScheduled: "2025-01-01T00:00:00Z",
+			InProgress:    rotating,
+		})
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/encryption/rotate", func(w http.ResponseWriter, r *http.Request) {
+		rotating = true
+		writeData(w, map[string]int64{"id": 9})
+	})
+
+	c := newTestClient(t, mux)
+
+	status, err := c.GetKeyRotationStatus(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetKeyRotationStatus()=%+v", err)
+	}
+
+	want := model.KeyRotationStatus{LastRotated: "2024-01-01T00:00:00Z", NextScheduled: "2025-01-01T00:00:00Z"}
+	if *status != want {
+		t.Fatalf("want %+v, got %+v", want, *status)
+	}
+
+	id, err := c.RotateEncryptionKey(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("RotateEncryptionKey()=%+v", err)
+	}
+	if id != 9 {
+		t.Fatalf("want request ID 9, got %d", id)
+	}
+
+	status, err = c.GetKeyRotationStatus(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetKeyRotationStatus()=%+v", err)
+	}
+	if !status.InProgress {
+		t.Fatal("want key rotation in progress")
+	}
+}
+
+func TestTerminationProtection(t *testing.T) {
+	var (
+		protected bool
+		deleted   int
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/termination-protection", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]bool{"enabled": protected})
+	})
+	mux.HandleFunc("PATCH /account/1/cluster/1/termination-protection", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Enabled bool `json:"enabled"`
+		}
+		readBody(t, r, &req)
+		protected = req.Enabled
+		writeData(w, nil)
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/delete", func(w http.ResponseWriter, r *http.Request) {
+		deleted++
+		writeData(w, model.ClusterRequest{ID: 5, Status: "QUEUED"})
+	})
+
+	c := newTestClient(t, mux)
+
+	if err := c.SetTerminationProtection(context.Background(), 1, true); err != nil {
+		t.Fatalf("SetTerminationProtection()=%+v", err)
+	}
+
+	got, err := c.GetTerminationProtection(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetTerminationProtection()=%+v", err)
+	}
+	if !got {
+		t.Fatal("want termination protection enabled")
+	}
+
+	if _, err := c.DeleteCluster(context.Background(), 1, "test"); !errors.Is(err, ErrTerminationProtected) {
+		t.Fatalf("want ErrTerminationProtected, got %+v", err)
+	}
+	if deleted != 0 {
+		t.Fatal("want protected cluster not to be deleted")
+	}
+
+	if err := c.SetTerminationProtection(context.Background(), 1, false); err != nil {
+		t.Fatalf("SetTerminationProtection()=%+v", err)
+	}
+
+	if _, err := c.DeleteCluster(context.Background(), 1, "test"); err != nil {
+		t.Fatalf("DeleteCluster()=%+v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("want cluster deleted once, got %d", deleted)
+	}
+}
+
+func TestDeleteClusterProtectionUnknown(t *testing.T) {
+	var deleted int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/termination-protection", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusForbidden, "Forbidden")
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/delete", func(w http.ResponseWriter, r *http.Request) {
+		deleted++
+		writeData(w, model.ClusterRequest{ID: 5, Status: "QUEUED"})
+	})
+
+	c := newTestClient(t, mux)
+
+	if _, err := c.DeleteCluster(context.Background(), 1, "test"); err != nil {
+		t.Fatalf("DeleteCluster()=%+v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("want cluster deleted once, got %d", deleted)
+	}
+}
+
+func TestSetBackupDestination(t *testing.T) {
+	var destinations []model.BackupDestination
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/backup/destinations", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]interface{}{"destinations": destinations})
+	})
+	mux.HandleFunc("PUT /account/1/cluster/1/backup/destination", func(w http.ResponseWriter, r *http.Request) {
+		var d model.BackupDestination
+		readBody(t, r, &d)
+		destinations = []model.BackupDestination{d}
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+
+	if err := c.SetBackupDestination(context.Background(), 1, &model.BackupDestination{
+		Type:   model.BackupDestinationS3,
+		Bucket: "My_Backups",
+		Region: "us-east-1",
+	}); err == nil {
+		t.Fatal("want error for invalid bucket name, got nil")
+	}
+	if destinations != nil {
+		t.Fatal("want invalid destination not to be set")
+	}
+
+	want := model.BackupDestination{Type: model.BackupDestinationS3, Bucket: "my-backups", Region: "us-east-1"}
+
+	if err := c.SetBackupDestination(context.Background(), 1, &want); err != nil {
+		t.Fatalf("SetBackupDestination()=%+v", err)
+	}
+
+	got, err := c.ListBackupDestinations(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListBackupDestinations()=%+v", err)
+	}
+	if !reflect.DeepEqual(got, []model.BackupDestination{want}) {
+		t.Fatalf("want %+v, got %+v", []model.BackupDestination{want}, got)
+	}
+}
+
+func TestListScalingEvents(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/scaling/events", serveTestdata(t, "scaling_events.json"))
+
+	c := newTestClient(t, mux)
+
+	events, err := c.ListScalingEvents(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListScalingEvents()=%+v", err)
+	}
+
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Reason)
+	}
+
+	if want := []string{"initial ramp-up", "instance upgrade", "traffic growth"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestCanAccessCluster(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusForbidden, "token is not scoped to this cluster")
+	})
+	mux.HandleFunc("GET /account/1/cluster/3", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Not Found")
+	})
+
+	c := newTestClient(t, mux)
+
+	ok, _, err := c.CanAccessCluster(context.Background(), 1)
+	if err != nil || !ok {
+		t.Fatalf("CanAccessCluster()=%t, %+v, want accessible", ok, err)
+	}
+
+	ok, reason, err := c.CanAccessCluster(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("CanAccessCluster()=%+v", err)
+	}
+	if ok || reason != "token is not scoped to this cluster" {
+		t.Fatalf("want forbidden with reason, got %t, %q", ok, reason)
+	}
+
+	if _, _, err := c.CanAccessCluster(context.Background(), 3); !IsNotFound(err) {
+		t.Fatalf("want not found error, got %+v", err)
+	}
+}
+
+func TestGetDCRegions(t *testing.T) {
+	var regionCalls int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/dcs", serveTestdata(t, "dc_regions.json"))
+	mux.HandleFunc("GET /deployment/cloud-provider/1/regions", func(w http.ResponseWriter, r *http.Request) {
+		regionCalls++
+		writeData(w, model.CloudProviderRegions{
+			Regions: []model.CloudProviderRegion{
+				{ID: 1, Name: "us-east-1", FullName: "US East (N. Virginia)"},
+				{ID: 7, Name: "eu-west-1", FullName: "Europe (Ireland)"},
+			},
+		})
+	})
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetDCRegions(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetDCRegions()=%+v", err)
+	}
+
+	want := map[string]string{
+		"AWS_US_EAST_1": "US East (N. Virginia)",
+		"AWS_EU_WEST_1": "Europe (Ireland)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	if regionCalls != 1 {
+		t.Fatalf("want regions listed once, got %d calls", regionCalls)
+	}
+}
+
+func TestMetricsSinks(t *testing.T) {
+	var sinks []model.MetricsSink
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/metrics/sinks", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]interface{}{"sinks": sinks})
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/metrics/sinks", func(w http.ResponseWriter, r *http.Request) {
+		var s model.MetricsSink
+		readBody(t, r, &s)
+		s.ID = int64(len(sinks) + 1)
+		sinks = append(sinks, s)
+		writeData(w, s)
+	})
+	mux.HandleFunc("DELETE /account/1/cluster/1/metrics/sinks/1", func(w http.ResponseWriter, r *http.Request) {
+		sinks = nil
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+
+	if _, err := c.CreateMetricsSink(context.Background(), 1, &model.MetricsSink{
+		Type:   model.MetricsSinkDatadog,
+		Config: map[string]string{"site": "datadoghq.com"},
+	}); err == nil {
+		t.Fatal("want error for missing api_key, got nil")
+	}
+
+	sink, err := c.CreateMetricsSink(context.Background(), 1, &model.MetricsSink{
+		Type:   model.MetricsSinkDatadog,
+		Config: map[string]string{"api_key": "k", "site": "datadoghq.com"},
+	})
+	if err != nil {
+		t.Fatalf("CreateMetricsSink()=%+v", err)
+	}
+
+	got, err := c.ListMetricsSinks(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListMetricsSinks()=%+v", err)
+	}
+	if !reflect.DeepEqual(got, []model.MetricsSink{*sink}) {
+		t.Fatalf("want %+v, got %+v", []model.MetricsSink{*sink}, got)
+	}
+
+	if err := c.DeleteMetricsSink(context.Background(), 1, sink.ID); err != nil {
+		t.Fatalf("DeleteMetricsSink()=%+v", err)
+	}
+	if len(sinks) != 0 {
+		t.Fatalf("want sink deleted, got %+v", sinks)
+	}
+}
+
+func TestGetLoadSheddingState(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1, PromProxyEnabled: true}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 2}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/metrics/load-shedding", serveTestdata(t, "load_shedding.json"))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetLoadSheddingState(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetLoadSheddingState()=%+v", err)
+	}
+
+	want := model.LoadShedding{Active: true, CPUThreshold: 0.9, QueueThreshold: 1000, ShedRequestsPerSec: 125.5}
+	if *got != want {
+		t.Fatalf("want %+v, got %+v", want, *got)
+	}
+
+	if _, err := c.GetLoadSheddingState(context.Background(), 2); !errors.Is(err, ErrMonitoringDisabled) {
+		t.Fatalf("want ErrMonitoringDisabled, got %+v", err)
+	}
+}
+
+func TestChangeClusterCIDR(t *testing.T) {
+	var changed string
+
+	cluster := func(id int64, status string) model.ClusterDetails {
+		return model.ClusterDetails{Cluster: model.Cluster{
+			ID:     id,
+			Status: status,
+			Datacenters: []model.Datacenter{
+				{ID: 1, CIDRBlock: "172.31.0.0/16"},
+				{ID: 2, CIDRBlock: "172.30.0.0/16"},
+			},
+		}}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, cluster(1, "Active"))
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, cluster(2, "RESIZING"))
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/network/vpc/peer", serveFixture(`{
+		"data": [{"id": 5, "cidrList": ["10.0.0.0/16"]}]
+	}`))
+	mux.HandleFunc("POST /account/1/cluster/1/dc/1/cidr", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			CIDRBlock string `json:"cidrBlock"`
+		}
+		readBody(t, r, &req)
+		changed = req.CIDRBlock
+		writeData(w, map[string]int64{"id": 11})
+	})
+
+	c := newTestClient(t, mux)
+
+	for _, cidr := range []string{"172.30.128.0/20", "10.0.64.0/18"} {
+		if _, err := c.ChangeClusterCIDR(context.Background(), 1, 1, cidr); err == nil || !strings.Contains(err.Error(), "overlaps") {
+			t.Fatalf("want overlap error for %s, got %+v", cidr, err)
+		}
+	}
+
+	if _, err := c.ChangeClusterCIDR(context.Background(), 2, 1, "192.168.0.0/16"); !errors.Is(err, ErrClusterNotActive) {
+		t.Fatalf("want ErrClusterNotActive, got %+v", err)
+	}
+
+	if changed != "" {
+		t.Fatalf("want no CIDR change requested, got %s", changed)
+	}
+
+	// The datacenter's own CIDR does not conflict with the new one.
+	id, err := c.ChangeClusterCIDR(context.Background(), 1, 1, "172.31.0.0/20")
+	if err != nil {
+		t.Fatalf("ChangeClusterCIDR()=%+v", err)
+	}
+	if id != 11 || changed != "172.31.0.0/20" {
+		t.Fatalf("want request 11 changing CIDR to 172.31.0.0/20, got %d, %s", id, changed)
+	}
+}
+
+func TestListIdentityProviders(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/sso/identity-providers", serveTestdata(t, "identity_providers.json"))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.ListIdentityProviders(context.Background())
+	if err != nil {
+		t.Fatalf("ListIdentityProviders()=%+v", err)
+	}
+
+	want := []model.IdentityProvider{
+		{ID: 1, Type: "SAML", Domain: "example.com", Enabled: true},
+		{ID: 2, Type: "OIDC", Domain: "corp.example.org"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestGetClusterNotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("enriched") != "true" {
+			t.Errorf("want enriched cluster requested, got %q", r.URL.RawQuery)
+		}
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1, ClusterName: "orders", Status: "ACTIVE"}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Not Found")
+	})
+
+	c := newTestClient(t, mux)
+
+	cluster, err := c.GetCluster(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetCluster()=%+v", err)
+	}
+	if cluster.ID != 1 || cluster.ClusterName != "orders" {
+		t.Fatalf("unexpected cluster: %+v", cluster)
+	}
+
+	cluster, err = c.GetCluster(context.Background(), 2)
+	if !IsNotFound(err) {
+		t.Fatalf("want not found error, got %+v", err)
+	}
+	if cluster != nil {
+		t.Fatalf("want no cluster on error, got %+v", cluster)
+	}
+}
+
+func TestListCostAnomalies(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/2/billing/anomalies", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("since"), "2024-06-01T00:00:00Z"; got != want {
+			t.Errorf("want since=%s, got %s", want, got)
+		}
+		serveTestdata(t, "cost_anomalies.json")(w, r)
+	})
+
+	c := newTestClient(t, mux)
+
+	got, err := c.ListCostAnomalies(context.Background(), 2, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ListCostAnomalies()=%+v", err)
+	}
+
+	want := []model.CostAnomaly{
+		{Date: "2024-06-03", Expected: 120.5, Actual: 310, Severity: "HIGH"},
+		{Date: "2024-06-10", Expected: 98.25, Actual: 131, Severity: "LOW"},
+		{Date: "2024-06-11", Expected: 120, Actual: 0, Severity: "LOW"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestManagerTasks(t *testing.T) {
+	var started []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/manager/tasks", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string][]model.ManagerTask{"tasks": {
+			{ID: "5f2c5b2e-repair", Type: "repair", Schedule: "0 3 * * *", Status: "DONE"},
+			{ID: "9a0d7c41-backup", Type: "backup", Schedule: "0 0 * * 0", Status: "NEW"},
+		}})
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/manager/tasks/{id}/start", func(w http.ResponseWriter, r *http.Request) {
+		started = append(started, r.PathValue("id"))
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+
+	tasks, err := c.ListManagerTasks(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListManagerTasks()=%+v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "5f2c5b2e-repair" || tasks[1].Type != "backup" {
+		t.Fatalf("unexpected tasks: %+v", tasks)
+	}
+
+	if err := c.TriggerManagerTask(context.Background(), 1, tasks[0].ID); err != nil {
+		t.Fatalf("TriggerManagerTask()=%+v", err)
+	}
+	if !reflect.DeepEqual(started, []string{"5f2c5b2e-repair"}) {
+		t.Fatalf("unexpected started tasks: %v", started)
+	}
+}
+
+func TestLatestScyllaVersion(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /deployment/scylla-versions", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ScyllaVersions{
+			DefaultScyllaVersionID: 1,
+			ScyllaVersions: []model.ScyllaVersion{
+				{VersionID: 1, Version: "2024.1.9", NewCluster: "ENABLED"},
+				{VersionID: 2, Version: "2024.1.10", NewCluster: "ENABLED"},
+				{VersionID: 3, Version: "2024.2.0-rc1", NewCluster: "ENABLED"},
+				{VersionID: 4, Version: "2025.1.0", NewCluster: "DISABLED"},
+			},
+		})
+	})
+
+	c := newTestClient(t, mux)
+
+	v, err := c.LatestScyllaVersion(context.Background())
+	if err != nil {
+		t.Fatalf("LatestScyllaVersion()=%+v", err)
+	}
+	if v.VersionID != 3 {
+		t.Fatalf("want version 2024.2.0-rc1, got %+v", v)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"2024.1.10", "2024.1.9", 1},
+		{"5.2", "5.2.0", 0},
+		{"2024.2.0-rc1", "2024.2.0", -1},
+		{"2024.2.0-rc2", "2024.2.0-rc1", 1},
+		{"5.4.9", "2024.1.0", -1},
+	}
+
+	for _, c := range cases {
+		if got := compareVersions(c.a, c.b); got != c.want {
+			t.Errorf("compareVersions(%q, %q)=%d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestGetCreateRequest(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/request", func(w http.ResponseWriter, r *http.Request) {
+		if typ := r.URL.Query().Get("type"); typ != "CREATE_CLUSTER" {
+			t.Errorf("want CREATE_CLUSTER requests, got %q", typ)
+		}
+		serveTestdata(t, "create_request.json")(w, r)
+	})
+	mux.HandleFunc("GET /account/1/cluster/2/request", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, []model.ClusterRequest{})
+	})
+
+	c := newTestClient(t, mux)
+
+	req, err := c.GetCreateRequest(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetCreateRequest()=%+v", err)
+	}
+	if req.ClusterName != "orders" || req.NumberOfNodes != 3 || req.CidrBlock != "172.31.0.0/16" || req.Tags["team"] != "billing" {
+		t.Fatalf("unexpected create request: %+v", req)
+	}
+
+	if _, err := c.GetCreateRequest(context.Background(), 2); !errors.Is(err, ErrNoCreateRequest) {
+		t.Fatalf("want ErrNoCreateRequest, got %+v", err)
+	}
+}
+
+func TestListInstanceTypes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /deployment/cloud-provider/1/region/2", serveTestdata(t, "instance_types.json"))
+
+	c := newTestClient(t, mux)
+
+	types, err := c.ListInstanceTypes(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("ListInstanceTypes()=%+v", err)
+	}
+	if len(types) != 2 {
+		t.Fatalf("want 2 instance types, got %d", len(types))
+	}
+
+	i := types[0]
+	if i.ID != 62 || i.ExternalID != "i4i.large" || i.Memory != 16384 || i.CPUCount != 2 || i.CostPerHour.String() != "0.172" {
+		t.Fatalf("unexpected instance type: %+v", i)
+	}
+	if types[1].Arch() != model.ArchitectureARM {
+		t.Fatalf("want arm64 instance type, got %+v", types[1])
+	}
+}
+
+func TestGetDiskConfig(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/disks", serveTestdata(t, "disk_config.json"))
+
+	c := newTestClient(t, mux)
+
+	disks, err := c.GetDiskConfig(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetDiskConfig()=%+v", err)
+	}
+
+	want := map[string]model.DiskConfig{
+		"AWS_US_EAST_1": {Type: "gp3", SizeGB: 1000, IOPS: 16000, ThroughputMBps: 1000},
+		"AWS_EU_WEST_1": {Type: "io2", SizeGB: 500, IOPS: 32000},
+	}
+
+	if !reflect.DeepEqual(disks, want) {
+		t.Fatalf("got %+v, want %+v", disks, want)
+	}
+}
+
+func TestResizeCluster(t *testing.T) {
+	var resized *model.ResizeRequest
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/dcs", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.Datacenters{Datacenters: []model.Datacenter{
+			{ID: 10, Name: "AWS_US_EAST_1", ReplicationFactor: 3},
+		}})
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/resize", func(w http.ResponseWriter, r *http.Request) {
+		resized = new(model.ResizeRequest)
+		readBody(t, r, resized)
+		writeData(w, model.ClusterRequest{ID: 7, Status: "QUEUED"})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1, Status: "RESIZING"}})
+	})
+
+	c := newTestClient(t, mux)
+
+	invalid := []model.DatacenterResize{
+		{DatacenterID: 10, NumberOfNodes: 2},
+		{DatacenterID: 10},
+		{DatacenterID: 20, NumberOfNodes: 6},
+	}
+
+	for _, dc := range invalid {
+		req := &model.ResizeRequest{Datacenters: []model.DatacenterResize{dc}}
+		if _, err := c.ResizeCluster(context.Background(), 1, req); err == nil {
+			t.Errorf("ResizeCluster(%+v): want error", dc)
+		}
+	}
+	if resized != nil {
+		t.Fatalf("want invalid resizes not to be sent, got %+v", resized)
+	}
+
+	req := &model.ResizeRequest{Datacenters: []model.DatacenterResize{{DatacenterID: 10, NumberOfNodes: 6}}}
+
+	cluster, err := c.ResizeCluster(context.Background(), 1, req)
+	if err != nil {
+		t.Fatalf("ResizeCluster()=%+v", err)
+	}
+	if cluster.Status != "RESIZING" {
+		t.Fatalf("unexpected cluster: %+v", cluster)
+	}
+	if !reflect.DeepEqual(resized, req) {
+		t.Fatalf("got resize request %+v, want %+v", resized, req)
+	}
+}
+
+func TestMonitoringRetention(t *testing.T) {
+	days := 15
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/metrics/retention", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]int{"retentionDays": days})
+	})
+	mux.HandleFunc("PUT /account/1/cluster/1/metrics/retention", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			RetentionDays int `json:"retentionDays"`
+		}
+		readBody(t, r, &req)
+		days = req.RetentionDays
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+
+	for _, d := range []time.Duration{0, 12 * time.Hour, 36 * time.Hour, 400 * 24 * time.Hour} {
+		if err := c.SetMonitoringRetention(context.Background(), 1, d); err == nil {
+			t.Errorf("SetMonitoringRetention(%s): want error", d)
+		}
+	}
+
+	if err := c.SetMonitoringRetention(context.Background(), 1, 30*24*time.Hour); err != nil {
+		t.Fatalf("SetMonitoringRetention()=%+v", err)
+	}
+
+	got, err := c.GetMonitoringRetention(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetMonitoringRetention()=%+v", err)
+	}
+	if got != 30*24*time.Hour {
+		t.Fatalf("got retention %s, want 720h", got)
+	}
+}
+
+func TestAddDataCenter(t *testing.T) {
+	var added []model.DatacenterRequest
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/dcs", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.Datacenters{Datacenters: []model.Datacenter{
+			{ID: 10, Name: "AWS_US_EAST_1", RegionID: 1, CIDRBlock: "172.31.0.0/16"},
+		}})
+	})
+	mux.HandleFunc("POST /account/1/cluster/1/dc", func(w http.ResponseWriter, r *http.Request) {
+		var req model.DatacenterRequest
+		readBody(t, r, &req)
+		added = append(added, req)
+		writeData(w, model.ClusterRequest{ID: 9, Status: "QUEUED"})
+	})
+
+	c := newTestClient(t, mux)
+
+	invalid := []model.DatacenterRequest{
+		{RegionID: 2, InstanceID: 10},
+		{RegionID: 1, InstanceID: 10, ReplicationFactor: 3},
+		{RegionID: 2, InstanceID: 10, ReplicationFactor: 3, CIDRBlock: "172.31.128.0/20"},
+	}
+
+	for _, req := range invalid {
+		if _, err := c.AddDataCenter(context.Background(), 1, &req); err == nil {
+			t.Errorf("AddDataCenter(%+v): want error", req)
+		}
+	}
+
+	req := model.DatacenterRequest{RegionID: 2, InstanceID: 10, ReplicationFactor: 3, CIDRBlock: "172.32.0.0/16"}
+
+	id, err := c.AddDataCenter(context.Background(), 1, &req)
+	if err != nil {
+		t.Fatalf("AddDataCenter()=%+v", err)
+	}
+	if id != 9 {
+		t.Fatalf("want request ID 9, got %d", id)
+	}
+	if !reflect.DeepEqual(added, []model.DatacenterRequest{req}) {
+		t.Fatalf("unexpected added datacenters: %+v", added)
+	}
+}
+
+func TestCheckDeletePreconditions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/network/vpc/peer", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, []model.VPCPeering{{ID: 3, VPCID: "vpc-1", Status: "DELETED"}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/shares", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Not Found")
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/manager/tasks", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string][]model.ManagerTask{"tasks": {{ID: "5f2c5b2e", Type: "repair", Status: "DONE"}}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2/network/vpc/peer", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, []model.VPCPeering{{ID: 4, VPCID: "vpc-2", Status: "ACTIVE"}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2/shares", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string][]model.ResourceShare{"shares": {{ID: 1, TargetAccountID: 7, Permission: model.SharePermissionRead}}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2/manager/tasks", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string][]model.ManagerTask{"tasks": {{ID: "9a0d7c41", Type: "backup", Status: "RUNNING"}}})
+	})
+
+	c := newTestClient(t, mux)
+
+	deps, err := c.CheckDeletePreconditions(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("CheckDeletePreconditions()=%+v", err)
+	}
+	if len(deps) != 0 {
+		t.Fatalf("want no dependents, got %q", deps)
+	}
+
+	deps, err = c.CheckDeletePreconditions(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("CheckDeletePreconditions()=%+v", err)
+	}
+
+	want := []string{
+		"VPC peering 4 with vpc-2",
+		"READ share with account 7",
+		"running backup task 9a0d7c41",
+	}
+
+	if !reflect.DeepEqual(deps, want) {
+		t.Fatalf("got dependents %q, want %q", deps, want)
+	}
+}
+
+func TestGetPerfSnapshot(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1, PromProxyEnabled: true}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 2}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/metrics/performance", serveTestdata(t, "perf_snapshot.json"))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetPerfSnapshot(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetPerfSnapshot()=%+v", err)
+	}
+
+	want := model.PerfSnapshot{ReadOpsPerSec: 18250.5, WriteOpsPerSec: 9120, P99ReadMs: 2.4, P99WriteMs: 1.1}
+	if *got != want {
+		t.Fatalf("want %+v, got %+v", want, *got)
+	}
+
+	if _, err := c.GetPerfSnapshot(context.Background(), 2); !errors.Is(err, ErrMonitoringDisabled) {
+		t.Fatalf("want ErrMonitoringDisabled, got %+v", err)
+	}
+}
+
+func TestListScyllaVersionsByEdition(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /deployment/scylla-versions", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ScyllaVersions{ScyllaVersions: []model.ScyllaVersion{
+			{VersionID: 1, Version: "5.4.9"},
+			{VersionID: 2, Version: "2024.1.10"},
+			{VersionID: 3, Version: "6.1.0", Edition: "OSS"},
+			{VersionID: 4, Version: "2024.2.0", Edition: "ENTERPRISE"},
+		}})
+	})
+
+	c := newTestClient(t, mux)
+
+	cases := map[string][]int64{
+		"ENTERPRISE": {2, 4},
+		"oss":        {1, 3},
+	}
+
+	for edition, want := range cases {
+		versions, err := c.ListScyllaVersionsByEdition(context.Background(), edition)
+		if err != nil {
+			t.Fatalf("ListScyllaVersionsByEdition(%q)=%+v", edition, err)
+		}
+
+		var got []int64
+		for _, v := range versions {
+			got = append(got, v.VersionID)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ListScyllaVersionsByEdition(%q)=%v, want %v", edition, got, want)
+		}
+	}
+
+	if _, err := c.ListScyllaVersionsByEdition(context.Background(), "community"); err == nil {
+		t.Fatal("want error for unsupported edition")
+	}
+}
+
+func TestGetMonitoringVersion(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/monitoring/version", serveTestdata(t, "monitoring_version.json"))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetMonitoringVersion(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetMonitoringVersion()=%+v", err)
+	}
+	if got != "4.8.1" {
+		t.Fatalf("want version 4.8.1, got %q", got)
+	}
+}
+
+func TestListClustersPages(t *testing.T) {
+	pages := map[string]model.Clusters{
+		"":   {Clusters: []model.Cluster{{ID: 1}, {ID: 2}}, NextPageToken: "p2"},
+		"p2": {Clusters: []model.Cluster{{ID: 3}}, NextPageToken: "p3"},
+		"p3": {Clusters: []model.Cluster{{ID: 4}}},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/clusters", func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Query().Get("pageToken")]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "BAD_TOKEN")
+			return
+		}
+		writeData(w, page)
+	})
+
+	c := newTestClient(t, mux)
+
+	clusters, err := c.ListClusters(context.Background())
+	if err != nil {
+		t.Fatalf("ListClusters()=%+v", err)
+	}
+	var ids []int64
+	for _, cluster := range clusters {
+		ids = append(ids, cluster.ID)
+	}
+	if want := []int64{1, 2, 3, 4}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("want %v, got %v", want, ids)
+	}
+
+	page, err := c.ListClustersPage(context.Background(), ListOptions{PageSize: 2, Cursor: "p2"})
+	if err != nil {
+		t.Fatalf("ListClustersPage()=%+v", err)
+	}
+	if len(page.Clusters) != 1 || page.NextPageToken != "p3" {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+}
+
+func TestListClustersRepeatedToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/clusters", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.Clusters{Clusters: []model.Cluster{{ID: 1}}, NextPageToken: "again"})
+	})
+
+	c := newTestClient(t, mux)
+
+	if _, err := c.ListClusters(context.Background()); err == nil {
+		t.Fatal("want error for repeated page token, got nil")
+	}
+}
+
+func TestGetInternodeEncryption(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1/encryption/internode", serveTestdata(t, "internode_encryption.json"))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetInternodeEncryption(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetInternodeEncryption()=%+v", err)
+	}
+	if !got {
+		t.Fatal("want internode encryption enabled, got disabled")
+	}
+}
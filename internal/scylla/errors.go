@@ -5,6 +5,39 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
+)
+
+// ErrMonitoringDisabled is returned when reading monitoring data of a cluster
+// that has the Prometheus proxy disabled.
+var ErrMonitoringDisabled = errors.New("monitoring is disabled for the cluster")
+
+// ErrCredentialAlreadyRetrieved is returned when the one-time initial
+// credentials of a cluster were already downloaded.
+var ErrCredentialAlreadyRetrieved = errors.New("initial credentials were already retrieved")
+
+// ErrNotSupported is returned when a feature is not available for the Scylla
+// version the cluster runs.
+var ErrNotSupported = errors.New("not supported by the cluster's Scylla version")
+
+// ErrTerminationProtected is returned when deleting a cluster that has
+// termination protection enabled.
+var ErrTerminationProtected = errors.New("cluster has termination protection enabled")
+
+// ErrClusterNotActive is returned when an operation requires the cluster
+// to be in the ACTIVE state.
+var ErrClusterNotActive = errors.New("cluster is not active")
+
+// ErrNoCreateRequest is returned when the API no longer retains the request
+// a cluster was created with.
+var ErrNoCreateRequest = errors.New("cluster create request is not retained")
+
+// Sentinel errors matched by an APIError with the corresponding HTTP status,
+// so callers can use errors.Is instead of inspecting the status code.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
 )
 
 func IsClusterDeletedErr(err error) bool {
@@ -29,10 +62,11 @@ func IsDeletedErr(err error) bool {
 }
 
 func IsNotFound(err error) bool {
-	if e := new(APIError); errors.As(err, &e) && e.StatusCode == http.StatusNotFound {
-		return true
-	}
-	return false
+	return errors.Is(err, ErrNotFound)
+}
+
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
 }
 
 // APIError represents an error that occurred while calling the API.
@@ -42,10 +76,33 @@ type APIError struct {
 	Message    string
 	Method     string
 	StatusCode int
+	RequestID  string        // value of the X-Request-Id response header, if any
+	RetryAfter time.Duration // value of the Retry-After response header, if any
+
+	raw string // response body as received
+}
+
+// Raw returns the body of the response the error was made from.
+func (err *APIError) Raw() string {
+	return err.raw
+}
+
+// Is reports whether the error matches one of the status sentinels:
+// ErrNotFound, ErrUnauthorized or ErrForbidden.
+func (err *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return err.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return err.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return err.StatusCode == http.StatusForbidden
+	}
+	return false
 }
 
-func makeError(text string, errCodes map[string]string, r *http.Response) *APIError {
-	var err APIError
+func makeError(text, raw string, errCodes map[string]string, r *http.Response) *APIError {
+	err := APIError{raw: raw}
 	if _, e := strconv.Atoi(text); e == nil {
 		err.Code = text
 
@@ -67,9 +124,29 @@ func makeError(text string, errCodes map[string]string, r *http.Response) *APIEr
 		err.StatusCode = r.StatusCode
 	}
 	err.Method = r.Request.Method
+	err.RequestID = r.Header.Get("X-Request-Id")
+	err.RetryAfter = retryAfter(r.Header.Get("Retry-After"))
 	return &err
 }
 
+// retryAfter parses the value of the Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func retryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(v); err == nil && n > 0 {
+		return time.Duration(n) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
 func (err *APIError) Error() string {
+	if err.RequestID != "" {
+		return fmt.Sprintf("Error %q: %s (http status %d, method %s url %q, request ID %s)", err.Code, err.Message, err.StatusCode, err.Method, err.URL, err.RequestID)
+	}
 	return fmt.Sprintf("Error %q: %s (http status %d, method %s url %q)", err.Code, err.Message, err.StatusCode, err.Method, err.URL)
 }
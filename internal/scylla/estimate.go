@@ -0,0 +1,131 @@
+package scylla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+var nodeDurations = sync.OnceValues(func() (map[string]string, error) {
+	return parse(durations, durationsDelim, durationsFunc)
+})
+
+// EstimateOperationDuration estimates how long it takes to provision
+// the cluster described by req. The estimate is the fixed cluster setup
+// time plus the average per-node provisioning time of the instance family,
+// as recorded in the bundled durations table, multiplied by the number
+// of nodes.
+func (c *Client) EstimateOperationDuration(req *model.ClusterCreateRequest) (time.Duration, error) {
+	if req.NumberOfNodes <= 0 {
+		return 0, errors.New("number of nodes must be positive")
+	}
+
+	d, err := nodeDurations()
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse durations: %w", err)
+	}
+
+	base, err := durationOf(d, "base")
+	if err != nil {
+		return 0, err
+	}
+
+	perNode, err := durationOf(d, instanceFamily(c.instanceName(req.CloudProviderID, req.InstanceID)))
+	if err != nil {
+		return 0, err
+	}
+
+	return base + time.Duration(req.NumberOfNodes)*perNode, nil
+}
+
+// hoursPerMonth is the average number of hours in a month used for
+// monthly cost estimates.
+const hoursPerMonth = 730
+
+// EstimateClusterCost estimates the cost of running the cluster described
+// by req, based on the instance cost per hour. The estimate is expressed in
+// the billing currency of the account, if the API reports one; no currency
+// conversion is made.
+func (c *Client) EstimateClusterCost(ctx context.Context, req *model.ClusterCreateRequest) (*model.CostEstimate, error) {
+	if req.NumberOfNodes <= 0 {
+		return nil, errors.New("number of nodes must be positive")
+	}
+
+	instance := c.instance(req.CloudProviderID, req.InstanceID)
+	if instance == nil {
+		return nil, fmt.Errorf("unknown instance %d of cloud provider %d", req.InstanceID, req.CloudProviderID)
+	}
+
+	perHour, err := instance.CostPerHour.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cost of instance %q: %w", instance.ExternalID, err)
+	}
+
+	currency, err := c.GetBillingCurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hourly := perHour * float64(req.NumberOfNodes)
+
+	return &model.CostEstimate{
+		Hourly:   hourly,
+		Monthly:  hourly * hoursPerMonth,
+		Currency: currency,
+	}, nil
+}
+
+func (c *Client) instance(providerID, instanceID int64) *model.CloudProviderInstance {
+	if c.Meta == nil {
+		return nil
+	}
+
+	p := c.Meta.ProviderByID(providerID)
+	if p == nil {
+		return nil
+	}
+
+	return p.InstanceByID(instanceID)
+}
+
+func (c *Client) instanceName(providerID, instanceID int64) string {
+	if i := c.instance(providerID, instanceID); i != nil {
+		return i.ExternalID
+	}
+
+	return ""
+}
+
+// instanceFamily returns the family of the instance type, e.g. "i4i" for
+// "i4i.large" (AWS) or "n2-highmem" for "n2-highmem-2" (GCP).
+func instanceFamily(name string) string {
+	if i := strings.IndexByte(name, '.'); i != -1 {
+		return name[:i]
+	}
+
+	if i := strings.LastIndexByte(name, '-'); i != -1 {
+		return name[:i]
+	}
+
+	return name
+}
+
+func durationOf(d map[string]string, key string) (time.Duration, error) {
+	v, ok := d[key]
+	if !ok {
+		v = d["default"]
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %q duration: %w", key, err)
+	}
+
+	return time.Duration(n) * time.Second, nil
+}
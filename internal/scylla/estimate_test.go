@@ -0,0 +1,123 @@
+package scylla
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+func TestEstimateOperationDuration(t *testing.T) {
+	c := &Client{
+		Meta: &Cloudmeta{
+			CloudProviders: []CloudProvider{{
+				CloudProvider: &model.CloudProvider{ID: 1, Name: "AWS"},
+				CloudProviderRegions: &model.CloudProviderRegions{
+					Instances: []model.CloudProviderInstance{{ID: 10, ExternalID: "i4i.large"}},
+				},
+			}},
+		},
+	}
+
+	estimate := func(nodes int64) int64 {
+		d, err := c.EstimateOperationDuration(&model.ClusterCreateRequest{
+			CloudProviderID: 1,
+			InstanceID:      10,
+			NumberOfNodes:   nodes,
+		})
+		if err != nil {
+			t.Fatalf("EstimateOperationDuration()=%+v", err)
+		}
+		return int64(d)
+	}
+
+	small, large := estimate(3), estimate(12)
+
+	if small >= large {
+		t.Fatalf("want small cluster estimate %d lower than large one %d", small, large)
+	}
+
+	// The per-node component of the estimate scales with the node count.
+	base := estimate(1) - (estimate(2) - estimate(1))
+	if got, want := large-base, 4*(small-base); got != want {
+		t.Fatalf("want per-node estimate %d, got %d", want, got)
+	}
+
+	if _, err := c.EstimateOperationDuration(&model.ClusterCreateRequest{}); err == nil {
+		t.Fatal("want error for zero nodes, got nil")
+	}
+}
+
+func TestEstimateClusterCost(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/billing/currency", serveFixture(`{
+		"data": {"currency": "EUR"}
+	}`))
+
+	c := newTestClient(t, mux)
+	c.Meta = &Cloudmeta{
+		CloudProviders: []CloudProvider{{
+			CloudProvider: &model.CloudProvider{ID: 1, Name: "AWS"},
+			CloudProviderRegions: &model.CloudProviderRegions{
+				Instances: []model.CloudProviderInstance{{ID: 10, ExternalID: "i4i.large", CostPerHour: "0.5"}},
+			},
+		}},
+	}
+
+	got, err := c.EstimateClusterCost(context.Background(), &model.ClusterCreateRequest{
+		CloudProviderID: 1,
+		InstanceID:      10,
+		NumberOfNodes:   3,
+	})
+	if err != nil {
+		t.Fatalf("EstimateClusterCost()=%+v", err)
+	}
+
+	want := model.CostEstimate{Hourly: 1.5, Monthly: 1.5 * hoursPerMonth, Currency: "EUR"}
+	if *got != want {
+		t.Fatalf("want %+v, got %+v", want, *got)
+	}
+
+	if _, err := c.EstimateClusterCost(context.Background(), &model.ClusterCreateRequest{
+		CloudProviderID: 1,
+		InstanceID:      11,
+		NumberOfNodes:   3,
+	}); err == nil {
+		t.Fatal("want error for unknown instance, got nil")
+	}
+}
+
+func TestEstimateClusterCostUnknownCurrency(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/billing/currency", serveFixture(`{
+		"data": {"currency": ""}
+	}`))
+
+	c := newTestClient(t, mux)
+	c.Meta = &Cloudmeta{
+		CloudProviders: []CloudProvider{{
+			CloudProvider: &model.CloudProvider{ID: 1, Name: "AWS"},
+			CloudProviderRegions: &model.CloudProviderRegions{
+				Instances: []model.CloudProviderInstance{{ID: 10, ExternalID: "i4i.large", CostPerHour: "0.5"}},
+			},
+		}},
+	}
+
+	// Account 1 reports an empty currency, account 2 none at all.
+	for _, id := range []int64{1, 2} {
+		c.AccountID = id
+
+		got, err := c.EstimateClusterCost(context.Background(), &model.ClusterCreateRequest{
+			CloudProviderID: 1,
+			InstanceID:      10,
+			NumberOfNodes:   3,
+		})
+		if err != nil {
+			t.Fatalf("EstimateClusterCost()=%+v", err)
+		}
+		if got.Currency != "" {
+			t.Fatalf("account %d: want no currency, got %q", id, got.Currency)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package scylla
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// clusterExport is the document produced by ExportClusterJSON. It holds
+// only the configuration of the cluster, leaving out IDs assigned by the
+// API, statuses and timestamps, so that it changes only when the
+// configuration does.
+type clusterExport struct {
+	Name              string             `json:"name"`
+	CloudProviderID   int64              `json:"cloudProviderId"`
+	ScyllaVersionID   int64              `json:"scyllaVersionId"`
+	UserAPIInterface  string             `json:"userApiInterface,omitempty"`
+	BroadcastType     string             `json:"broadcastType,omitempty"`
+	ReplicationFactor int64              `json:"replicationFactor,omitempty"`
+	Datacenters       []datacenterExport `json:"datacenters"`
+	AllowedIPs        []string           `json:"allowedIPs"`
+	VPCPeerings       []vpcPeeringExport `json:"vpcPeerings"`
+	Tags              map[string]string  `json:"tags"`
+}
+
+type datacenterExport struct {
+	Name              string `json:"name"`
+	RegionID          int64  `json:"regionId"`
+	InstanceID        int64  `json:"instanceId"`
+	ReplicationFactor int64  `json:"replicationFactor"`
+	CIDRBlock         string `json:"cidrBlock"`
+}
+
+type vpcPeeringExport struct {
+	VPCID    string   `json:"vpcId"`
+	OwnerID  string   `json:"ownerId"`
+	RegionID int64    `json:"regionId"`
+	CIDRList []string `json:"cidrList"`
+	AllowCQL bool     `json:"allowCql"`
+}
+
+// ExportClusterJSON returns the configuration of the cluster, its
+// datacenters, allowlist, VPC peerings and tags as an indented JSON
+// document suitable for version control. Lists are sorted and volatile
+// fields are left out, so exporting an unchanged cluster yields the same
+// bytes.
+func (c *Client) ExportClusterJSON(ctx context.Context, clusterID int64) ([]byte, error) {
+	cluster, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster: %w", err)
+	}
+
+	dcs, err := c.ListDataCenters(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading datacenters: %w", err)
+	}
+
+	rules, err := c.ListAllowlistRules(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing allowlist rules: %w", err)
+	}
+
+	peerings, err := c.ListClusterVPCPeerings(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing VPC peerings: %w", err)
+	}
+
+	tags, err := c.GetClusterTags(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading tags: %w", err)
+	}
+
+	doc := clusterExport{
+		Name:              cluster.ClusterName,
+		CloudProviderID:   cluster.CloudProviderID,
+		ScyllaVersionID:   cluster.ScyllaVersionID,
+		UserAPIInterface:  cluster.UserAPIInterface,
+		BroadcastType:     cluster.BroadcastType,
+		ReplicationFactor: cluster.ReplicationFactor,
+		Datacenters:       make([]datacenterExport, 0, len(dcs)),
+		AllowedIPs:        make([]string, 0, len(rules)),
+		VPCPeerings:       make([]vpcPeeringExport, 0, len(peerings)),
+		Tags:              tags,
+	}
+
+	if doc.Tags == nil {
+		doc.Tags = make(map[string]string)
+	}
+
+	for _, dc := range dcs {
+		doc.Datacenters = append(doc.Datacenters, datacenterExport{
+			Name:              dc.Name,
+			RegionID:          dc.RegionID,
+			InstanceID:        dc.InstanceID,
+			ReplicationFactor: dc.ReplicationFactor,
+			CIDRBlock:         dc.CIDRBlock,
+		})
+	}
+
+	for _, r := range rules {
+		doc.AllowedIPs = append(doc.AllowedIPs, r.Address)
+	}
+
+	for _, p := range peerings {
+		cidrs := slices.Clone(p.CIDRList)
+		slices.Sort(cidrs)
+
+		doc.VPCPeerings = append(doc.VPCPeerings, vpcPeeringExport{
+			VPCID:    p.VPCID,
+			OwnerID:  p.OwnerID,
+			RegionID: p.RegionID,
+			CIDRList: cidrs,
+			AllowCQL: p.AllowCQL,
+		})
+	}
+
+	slices.SortFunc(doc.Datacenters, func(a, b datacenterExport) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	slices.Sort(doc.AllowedIPs)
+	slices.SortFunc(doc.VPCPeerings, func(a, b vpcPeeringExport) int {
+		return cmp.Or(cmp.Compare(a.VPCID, b.VPCID), cmp.Compare(a.RegionID, b.RegionID))
+	})
+
+	p, err := json.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(p, '\n'), nil
+}
@@ -0,0 +1,73 @@
+package scylla
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+func TestExportClusterJSON(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{
+			ID:                1,
+			ClusterName:       "orders",
+			Status:            "ACTIVE",
+			CloudProviderID:   1,
+			ScyllaVersionID:   143,
+			UserAPIInterface:  "CQL",
+			BroadcastType:     "PRIVATE",
+			ReplicationFactor: 3,
+			CreatedAt:         "2024-05-02T08:00:00Z",
+		}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/dcs", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.Datacenters{Datacenters: []model.Datacenter{
+			{ID: 12, Name: "AWS_US_EAST_1", Status: "ACTIVE", RegionID: 1, InstanceID: 62, ReplicationFactor: 3, CIDRBlock: "172.31.0.0/16"},
+			{ID: 11, Name: "AWS_EU_WEST_1", Status: "ACTIVE", RegionID: 2, InstanceID: 62, ReplicationFactor: 3, CIDRBlock: "172.32.0.0/16"},
+		}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/network/firewall/allowed", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, []model.AllowedIP{{ID: 7, Address: "192.168.0.0/24"}, {ID: 5, Address: "10.0.0.1"}})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/network/vpc/peer", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, []model.VPCPeering{
+			{ID: 9, VPCID: "vpc-2", OwnerID: "123456789012", RegionID: 1, CIDRList: []string{"10.2.0.0/16"}, Status: "ACTIVE", ExpiresAt: "2024-06-01T00:00:00Z"},
+			{ID: 8, VPCID: "vpc-1", OwnerID: "123456789012", RegionID: 1, CIDRList: []string{"10.1.128.0/17", "10.1.0.0/17"}, Status: "ACTIVE", AllowCQL: true},
+		})
+	})
+	mux.HandleFunc("GET /account/1/cluster/1/tags", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterTags{Tags: map[string]string{"team": "billing", "env": "prod"}})
+	})
+
+	c := newTestClient(t, mux)
+
+	got, err := c.ExportClusterJSON(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ExportClusterJSON()=%+v", err)
+	}
+
+	golden := filepath.Join("testdata", "cluster_export.golden.json")
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("ReadFile()=%+v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("export does not match %s:\n%s", golden, got)
+	}
+
+	again, err := c.ExportClusterJSON(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ExportClusterJSON()=%+v", err)
+	}
+	if !bytes.Equal(got, again) {
+		t.Fatal("want repeated exports to be identical")
+	}
+}
@@ -0,0 +1,83 @@
+package scylla
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+// Free tier clusters have a fixed size.
+const (
+	freeTierNodes             = 3
+	freeTierReplicationFactor = 3
+)
+
+// ResolveFreeTierDefaults returns a create request filled with the free tier
+// configuration of the cloud provider: its default region and the first
+// instance type eligible for the free tier. Only the cluster name needs to
+// be set before passing it to CreateCluster.
+func (c *Client) ResolveFreeTierDefaults(providerName string) (*model.ClusterCreateRequest, error) {
+	if c.Meta == nil {
+		return nil, errors.New("cloud provider metadata is not loaded")
+	}
+
+	p := c.Meta.ProviderByName(providerName)
+	if p == nil {
+		return nil, fmt.Errorf("unrecognized cloud provider %q", providerName)
+	}
+
+	i := p.instanceByFunc(func(t *model.CloudProviderInstance) bool {
+		return t.FreeTierHours > 0
+	})
+	if i == nil {
+		return nil, fmt.Errorf("cloud provider %q has no free tier instance type", providerName)
+	}
+
+	return &model.ClusterCreateRequest{
+		CloudProviderID:   p.CloudProvider.ID,
+		RegionID:          p.CloudProviderRegions.DefaultRegionID,
+		InstanceID:        i.ID,
+		NumberOfNodes:     freeTierNodes,
+		ReplicationFactor: freeTierReplicationFactor,
+		FreeTier:          true,
+	}, nil
+}
+
+// validateFreeTier checks a free tier create request against the free tier
+// limits. The region and instance type are checked only if the cloud
+// provider metadata is loaded. Serverless clusters are provisioned with
+// their own fixed configuration and are not checked.
+func (c *Client) validateFreeTier(req *model.ClusterCreateRequest) error {
+	if strings.EqualFold(req.Provisioning, model.DeploymentServerless) {
+		return nil
+	}
+
+	if req.NumberOfNodes != freeTierNodes {
+		return fmt.Errorf("free tier clusters have %d nodes, got %d", freeTierNodes, req.NumberOfNodes)
+	}
+
+	if req.ReplicationFactor != freeTierReplicationFactor {
+		return fmt.Errorf("free tier clusters have a replication factor of %d, got %d", freeTierReplicationFactor, req.ReplicationFactor)
+	}
+
+	if c.Meta == nil {
+		return nil
+	}
+
+	p := c.Meta.ProviderByID(req.CloudProviderID)
+	if p == nil {
+		return fmt.Errorf("unrecognized cloud provider %d", req.CloudProviderID)
+	}
+
+	if id := p.CloudProviderRegions.DefaultRegionID; id != 0 && req.RegionID != id {
+		return fmt.Errorf("free tier clusters are available in region %d only, got %d", id, req.RegionID)
+	}
+
+	if i := p.InstanceByID(req.InstanceID); i == nil || i.FreeTierHours <= 0 {
+		return fmt.Errorf("instance type %d is not available in the free tier", req.InstanceID)
+	}
+
+	return nil
+}
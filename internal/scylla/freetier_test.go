@@ -0,0 +1,77 @@
+package scylla
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+func freeTierMeta() *Cloudmeta {
+	return &Cloudmeta{
+		CloudProviders: []CloudProvider{{
+			CloudProvider: &model.CloudProvider{ID: 1, Name: "AWS"},
+			CloudProviderRegions: &model.CloudProviderRegions{
+				DefaultRegionID: 2,
+				Instances: []model.CloudProviderInstance{
+					{ID: 10, ExternalID: "i4i.xlarge"},
+					{ID: 11, ExternalID: "t3.micro", FreeTierHours: 720},
+				},
+			},
+		}},
+	}
+}
+
+func TestResolveFreeTierDefaults(t *testing.T) {
+	c := &Client{Meta: freeTierMeta()}
+
+	got, err := c.ResolveFreeTierDefaults("aws")
+	if err != nil {
+		t.Fatalf("ResolveFreeTierDefaults()=%+v", err)
+	}
+
+	want := model.ClusterCreateRequest{
+		CloudProviderID:   1,
+		RegionID:          2,
+		InstanceID:        11,
+		NumberOfNodes:     3,
+		ReplicationFactor: 3,
+		FreeTier:          true,
+	}
+
+	if !reflect.DeepEqual(*got, want) {
+		t.Fatalf("want %+v, got %+v", want, *got)
+	}
+
+	if _, err := c.ResolveFreeTierDefaults("Azure"); err == nil {
+		t.Fatal("want error for unknown provider, got nil")
+	}
+}
+
+func TestCreateClusterFreeTierOverride(t *testing.T) {
+	c := newTestClient(t, http.NewServeMux())
+	c.Meta = freeTierMeta()
+
+	overrides := map[string]func(*model.ClusterCreateRequest){
+		"instance": func(r *model.ClusterCreateRequest) { r.InstanceID = 10 },
+		"region":   func(r *model.ClusterCreateRequest) { r.RegionID = 3 },
+		"nodes":    func(r *model.ClusterCreateRequest) { r.NumberOfNodes = 6 },
+	}
+
+	for name, override := range overrides {
+		req, err := c.ResolveFreeTierDefaults("AWS")
+		if err != nil {
+			t.Fatalf("ResolveFreeTierDefaults()=%+v", err)
+		}
+
+		req.ClusterName = "free"
+		override(req)
+
+		if _, err := c.CreateCluster(context.Background(), req); err == nil || !strings.Contains(err.Error(), "free tier") {
+			t.Errorf("%s override: want free tier error, got %+v", name, err)
+		}
+	}
+}
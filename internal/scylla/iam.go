@@ -0,0 +1,90 @@
+package scylla
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type awsPolicy struct {
+	Version   string         `json:"Version"`
+	Statement []awsStatement `json:"Statement"`
+}
+
+type awsStatement struct {
+	Effect    string            `json:"Effect"`
+	Principal map[string]string `json:"Principal"`
+	Action    string            `json:"Action"`
+}
+
+type gcpPolicy struct {
+	Bindings []gcpBinding `json:"bindings"`
+}
+
+type gcpBinding struct {
+	Role    string   `json:"role"`
+	Members []string `json:"members"`
+}
+
+// gcpRoles lists the roles Scylla Cloud needs in a BYOC project.
+var gcpRoles = []string{
+	"roles/compute.admin",
+	"roles/iam.serviceAccountUser",
+}
+
+// GenerateIAMPolicy returns the JSON policy document a BYOC user attaches
+// to grant Scylla Cloud access to their cloud account:
+//
+//   - for AWS, the trust policy of the cross-account role, allowing
+//     the Scylla Cloud root account to assume it;
+//   - for GCP, the project IAM bindings for the Scylla Cloud service account.
+func (c *Client) GenerateIAMPolicy(ctx context.Context, providerName string) (string, error) {
+	providers, err := c.ListCloudProviders(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error reading cloud providers: %w", err)
+	}
+
+	var rootAccountID string
+	for i := range providers {
+		if strings.EqualFold(providers[i].Name, providerName) {
+			rootAccountID = providers[i].RootAccountID
+			break
+		}
+	}
+
+	if rootAccountID == "" {
+		return "", fmt.Errorf("unable to find root account of %q cloud provider", providerName)
+	}
+
+	var policy interface{}
+
+	switch strings.ToUpper(providerName) {
+	case "AWS":
+		policy = awsPolicy{
+			Version: "2012-10-17",
+			Statement: []awsStatement{{
+				Effect:    "Allow",
+				Principal: map[string]string{"AWS": "arn:aws:iam::" + rootAccountID + ":root"},
+				Action:    "sts:AssumeRole",
+			}},
+		}
+	case "GCP":
+		member := "serviceAccount:scylla-cloud@" + rootAccountID + ".iam.gserviceaccount.com"
+
+		var p gcpPolicy
+		for _, role := range gcpRoles {
+			p.Bindings = append(p.Bindings, gcpBinding{Role: role, Members: []string{member}})
+		}
+		policy = p
+	default:
+		return "", fmt.Errorf("IAM policy is not supported for %q cloud provider", providerName)
+	}
+
+	p, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(p), nil
+}
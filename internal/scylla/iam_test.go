@@ -0,0 +1,48 @@
+package scylla
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+func TestGenerateIAMPolicy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /deployment/cloud-providers", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.CloudProviders{CloudProviders: []model.CloudProvider{
+			{ID: 1, Name: "AWS", RootAccountID: "123456789012"},
+			{ID: 2, Name: "GCP", RootAccountID: "scylla-cloud-prod"},
+		}})
+	})
+
+	c := newTestClient(t, mux)
+
+	for _, provider := range []string{"AWS", "GCP"} {
+		t.Run(provider, func(t *testing.T) {
+			got, err := c.GenerateIAMPolicy(context.Background(), provider)
+			if err != nil {
+				t.Fatalf("GenerateIAMPolicy()=%+v", err)
+			}
+
+			golden := filepath.Join("testdata", "iam_policy_"+strings.ToLower(provider)+".golden.json")
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("ReadFile()=%+v", err)
+			}
+
+			if got != strings.TrimSpace(string(want)) {
+				t.Fatalf("policy does not match %s:\n%s", golden, got)
+			}
+		})
+	}
+
+	if _, err := c.GenerateIAMPolicy(context.Background(), "Azure"); err == nil {
+		t.Fatal("want error for unknown provider, got nil")
+	}
+}
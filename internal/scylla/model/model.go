@@ -2,6 +2,12 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -21,6 +27,29 @@ type ScyllaVersion struct {
 	Version     string `json:"version"`
 	Description string `json:"description"`
 	NewCluster  string `json:"newCluster"`
+	Edition     string `json:"edition"`
+}
+
+// Scylla editions.
+const (
+	EditionEnterprise = "enterprise"
+	EditionOSS        = "oss"
+)
+
+// GetEdition returns the Scylla edition of the version. Versions with no
+// edition reported by the API are classified by their numbering: Enterprise
+// releases are numbered by year, such as 2024.1.
+func (v *ScyllaVersion) GetEdition() string {
+	if v.Edition != "" {
+		return strings.ToLower(v.Edition)
+	}
+
+	major, _, _ := strings.Cut(v.Version, ".")
+	if n, err := strconv.Atoi(major); err == nil && n >= 2000 {
+		return EditionEnterprise
+	}
+
+	return EditionOSS
 }
 
 type ScyllaVersions struct {
@@ -61,6 +90,26 @@ type CloudProviderInstance struct {
 	SubscriptionCostHourly      json.Number `json:"subscriptionCostHourly"`
 	InstanceCostHourly          json.Number `json:"instanceCostHourly"`
 	FreeTierHours               int64       `json:"freeTierHours"`
+	Architecture                string      `json:"architecture"`
+}
+
+// Instance architectures.
+const (
+	ArchitectureX86 = "x86"
+	ArchitectureARM = "arm"
+)
+
+// Arch returns the instance architecture, instances with no architecture
+// reported by the API are assumed to be x86.
+func (t *CloudProviderInstance) Arch() string {
+	switch strings.ToLower(t.Architecture) {
+	case "", "x86", "x86_64", "amd64":
+		return ArchitectureX86
+	case "arm", "arm64", "aarch64":
+		return ArchitectureARM
+	default:
+		return strings.ToLower(t.Architecture)
+	}
 }
 
 type CloudProviderRegions struct {
@@ -89,27 +138,96 @@ type ClusterRequest struct {
 	Status              string `json:"status"`
 }
 
+type ClusterEvent struct {
+	ID        int64  `json:"id"`
+	RequestID int64  `json:"requestId"`
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+}
+
 type ClusterCreateRequest struct {
-	AccountCredentialID      int64    `json:"accountCredentialId,omitempty"`
-	AlternatorWriteIsolation string   `json:"alternatorWriteIsolation,omitempty"`
-	BroadcastType            string   `json:"broadcastType,omitempty"`
-	CidrBlock                string   `json:"cidrBlock,omitempty"`
-	CloudProviderID          int64    `json:"cloudProviderId,omitempty"`
-	InstanceID               int64    `json:"instanceId,omitempty"`
-	RegionID                 int64    `json:"regionId,omitempty"`
-	EnableDNSAssociation     bool     `json:"enableDnsAssociation"`
-	AllowedIPs               []string `json:"allowedIPs,omitempty"`
-	FreeTier                 bool     `json:"freeTier"`
-	JumpStart                bool     `json:"jumpStart"`
-	ClusterName              string   `json:"clusterName"`
-	NumberOfNodes            int64    `json:"numberOfNodes"`
-	PromProxy                bool     `json:"promProxy"`
-	ReplicationFactor        int64    `json:"replicationFactor"`
-	ScyllaVersionID          int64    `json:"scyllaVersionId,omitempty"`
-	UserAPIInterface         string   `json:"userApiInterface,omitempty"`
-	Provisioning             string   `json:"provisioning,omitempty"`
-	ProcessingUnits          int      `json:"pu,omitempty" minimum:"1" maximum:"1000" default:"1"`
-	Expiration               string   `json:"expiration,omitempty" example:"12"`
+	AccountCredentialID      int64             `json:"accountCredentialId,omitempty"`
+	AlternatorWriteIsolation string            `json:"alternatorWriteIsolation,omitempty"`
+	BroadcastType            string            `json:"broadcastType,omitempty"`
+	CidrBlock                string            `json:"cidrBlock,omitempty"`
+	CloudProviderID          int64             `json:"cloudProviderId,omitempty"`
+	InstanceID               int64             `json:"instanceId,omitempty"`
+	RegionID                 int64             `json:"regionId,omitempty"`
+	EnableDNSAssociation     bool              `json:"enableDnsAssociation"`
+	AllowedIPs               []string          `json:"allowedIPs,omitempty"`
+	FreeTier                 bool              `json:"freeTier"`
+	JumpStart                bool              `json:"jumpStart"`
+	ClusterName              string            `json:"clusterName"`
+	NumberOfNodes            int64             `json:"numberOfNodes"`
+	PromProxy                bool              `json:"promProxy"`
+	ReplicationFactor        int64             `json:"replicationFactor"`
+	ScyllaVersionID          int64             `json:"scyllaVersionId,omitempty"`
+	UserAPIInterface         string            `json:"userApiInterface,omitempty"`
+	Provisioning             string            `json:"provisioning,omitempty"`
+	ProcessingUnits          int               `json:"pu,omitempty" minimum:"1" maximum:"1000" default:"1"`
+	Expiration               string            `json:"expiration,omitempty" example:"12"`
+	Tags                     map[string]string `json:"tags,omitempty"`
+}
+
+// Validate checks that the fields required to create a cluster are set.
+func (r *ClusterCreateRequest) Validate() error {
+	var missing []string
+
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"cluster name", r.ClusterName != ""},
+		{"cloud provider ID", r.CloudProviderID != 0},
+		{"region ID", r.RegionID != 0},
+		{"instance ID", r.InstanceID != 0},
+		{"number of nodes", r.NumberOfNodes > 0},
+		{"replication factor", r.ReplicationFactor > 0},
+	} {
+		if !f.set {
+			missing = append(missing, f.name)
+		}
+	}
+
+	if len(missing) != 0 {
+		return fmt.Errorf("invalid cluster create request, missing: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// Merge returns a copy of r with the non-zero fields of o applied on top.
+// Tags are merged key by key, with the tags of o taking precedence. Boolean
+// fields can only be enabled by o, as their zero value means "not set".
+func (r ClusterCreateRequest) Merge(o *ClusterCreateRequest) ClusterCreateRequest {
+	dst := reflect.ValueOf(&r).Elem()
+	src := reflect.ValueOf(o).Elem()
+
+	for i := 0; i < dst.NumField(); i++ {
+		if f := src.Field(i); !f.IsZero() && f.Kind() != reflect.Map {
+			dst.Field(i).Set(f)
+		}
+	}
+
+	if len(o.Tags) != 0 {
+		tags := make(map[string]string, len(r.Tags)+len(o.Tags))
+		for k, v := range r.Tags {
+			tags[k] = v
+		}
+		for k, v := range o.Tags {
+			tags[k] = v
+		}
+		r.Tags = tags
+	}
+
+	return r
+}
+
+type ClusterTemplate struct {
+	ID   int64                `json:"id"`
+	Name string               `json:"name"`
+	Spec ClusterCreateRequest `json:"spec"`
 }
 
 type Cluster struct {
@@ -122,6 +240,7 @@ type Cluster struct {
 	ScyllaVersionID     int64                  `json:"scyllaVersionID"`
 	UserAPIInterface    string                 `json:"userApiInterface"`
 	PricingModel        int64                  `json:"pricingModel"`
+	Provisioning        string                 `json:"provisioning,omitempty"`
 	MaxAllowedCIDRRange int64                  `json:"maxAllowedCidrRange"`
 	DNS                 bool                   `json:"dns"`
 	CloudProvider       *CloudProvider         `json:"cloudProvider"`
@@ -147,6 +266,31 @@ type Cluster struct {
 	AlternatorWriteIsolation string       `json:"alternatorWriteIsolation,omitempty"`
 }
 
+// Deployment models reported by Cluster.DeploymentModel.
+const (
+	DeploymentDedicated  = "dedicated"
+	DeploymentServerless = "serverless"
+	DeploymentFreeTier   = "free-tier"
+)
+
+// DeploymentModel classifies the cluster deployment:
+//
+//   - "free-tier" if the cluster has free tier expiration set, regardless
+//     of its provisioning;
+//   - "serverless" if the cluster reports the same "serverless"
+//     provisioning it is created with (see ClusterCreateRequest);
+//   - "dedicated" otherwise.
+func (cl Cluster) DeploymentModel() string {
+	switch {
+	case cl.FreeTier != nil:
+		return DeploymentFreeTier
+	case strings.EqualFold(cl.Provisioning, DeploymentServerless):
+		return DeploymentServerless
+	default:
+		return DeploymentDedicated
+	}
+}
+
 type Progress struct {
 	ProgressPercent     int64  `json:"ProgressPercent"`
 	ProgressDescription string `json:"ProgressDescription"`
@@ -154,6 +298,9 @@ type Progress struct {
 
 type Clusters struct {
 	Clusters []Cluster `json:"clusters"`
+	// NextPageToken is set when more clusters are available; it is
+	// passed back as the cursor to read the next page.
+	NextPageToken string `json:"nextPageToken,omitempty"`
 }
 
 type ExpirationTime struct {
@@ -177,12 +324,43 @@ type Datacenter struct {
 	Region                           *CloudProviderRegion `json:"region,omitempty"`
 }
 
+// DatacenterRequest describes a datacenter to be added to a cluster.
+type DatacenterRequest struct {
+	CloudProviderID   int64  `json:"cloudProviderId,omitempty"`
+	RegionID          int64  `json:"regionId"`
+	InstanceID        int64  `json:"instanceId"`
+	ReplicationFactor int64  `json:"replicationFactor"`
+	CIDRBlock         string `json:"cidrBlock,omitempty"`
+}
+
+// ResizeRequest changes the number of nodes, or the instance type, of
+// the given datacenters of a cluster.
+type ResizeRequest struct {
+	Datacenters []DatacenterResize `json:"dcNodes"`
+}
+
+type DatacenterResize struct {
+	DatacenterID int64 `json:"dcId"`
+	// NumberOfNodes is the wanted node count, zero keeps the current one.
+	NumberOfNodes int64 `json:"wantedSize,omitempty"`
+	// InstanceID is the wanted instance type, zero keeps the current one.
+	InstanceID int64 `json:"instanceTypeId,omitempty"`
+}
+
 type AllowedIP struct {
 	ID        int64  `json:"id"`
 	ClusterID int64  `json:"clusterId"`
 	Address   string `json:"address"`
 }
 
+// FirewallRule is an allowlist rule as evaluated by the cluster firewall,
+// rules with lower Priority are evaluated first.
+type FirewallRule struct {
+	AllowedIP
+	Priority int64  `json:"priority"`
+	Action   string `json:"action"`
+}
+
 type Node struct {
 	BillingStartDate string               `json:"billingStartDate"`
 	CloudProviderID  int64                `json:"cloudProviderID"`
@@ -200,6 +378,7 @@ type Node struct {
 	ServiceID        int64                `json:"serviceID"`
 	ServiceVersionID int64                `json:"serviceVersionID"`
 	Status           string               `json:"status"`
+	AvailabilityZone string               `json:"availabilityZone"`
 }
 
 type AccountDeal struct {
@@ -279,6 +458,11 @@ type ClusterConnection struct {
 	Type              string            `json:"type"`
 }
 
+// CQLPort is the port clients connect to the cluster nodes on.
+const CQLPort = 9042
+
+// ClusterConnectionInformation holds what clients need to connect to
+// the cluster. Credentials.Password is sensitive and must not be logged.
 type ClusterConnectionInformation struct {
 	BroadcastType string `json:"broadcastType"`
 	Credentials   struct {
@@ -332,8 +516,451 @@ type Datacenters struct {
 	Datacenters []Datacenter `json:"dataCenters"`
 }
 
+type ClusterTags struct {
+	Tags map[string]string `json:"tags"`
+}
+
+// Sources of effective tags.
+const (
+	TagSourceAccount = "account"
+	TagSourceCluster = "cluster"
+)
+
+// EffectiveTag is a tag applied to a cluster, along with where it is set.
+type EffectiveTag struct {
+	Value  string
+	Source string
+}
+
+type TLSConfig struct {
+	MinVersion string   `json:"minVersion"`
+	Ciphers    []string `json:"ciphers"`
+	ClientAuth bool     `json:"clientAuth"`
+}
+
+type InitialCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type SLAStatus struct {
+	UptimePercent float64 `json:"uptimePercent"`
+	PeriodStart   string  `json:"periodStart"`
+	Breaches      int     `json:"breaches"`
+}
+
+func (s *SLAStatus) UnmarshalJSON(p []byte) error {
+	var v struct {
+		UptimePercent json.Number `json:"uptimePercent"`
+		PeriodStart   string      `json:"periodStart"`
+		Breaches      json.Number `json:"breaches"`
+	}
+
+	if err := json.Unmarshal(p, &v); err != nil {
+		return err
+	}
+
+	uptime, err := numberFloat(v.UptimePercent)
+	if err != nil {
+		return fmt.Errorf("invalid uptimePercent: %w", err)
+	}
+
+	breaches, err := numberInt(v.Breaches)
+	if err != nil {
+		return fmt.Errorf("invalid breaches: %w", err)
+	}
+
+	*s = SLAStatus{
+		UptimePercent: uptime,
+		PeriodStart:   v.PeriodStart,
+		Breaches:      int(breaches),
+	}
+
+	return nil
+}
+
+type Keyspace struct {
+	Name                string `json:"name"`
+	ReplicationStrategy string `json:"replicationStrategy"`
+	Tables              int    `json:"tables"`
+}
+
+// IsSystem reports whether the keyspace is an internal Scylla keyspace.
+func (k *Keyspace) IsSystem() bool {
+	return strings.HasPrefix(k.Name, "system")
+}
+
+type QueryTimeouts struct {
+	ReadTimeoutMs  int64 `json:"readTimeoutMs"`
+	WriteTimeoutMs int64 `json:"writeTimeoutMs"`
+	RangeTimeoutMs int64 `json:"rangeTimeoutMs"`
+}
+
+func (t *QueryTimeouts) Validate() error {
+	switch {
+	case t.ReadTimeoutMs <= 0:
+		return fmt.Errorf("read timeout must be positive, got %dms", t.ReadTimeoutMs)
+	case t.WriteTimeoutMs <= 0:
+		return fmt.Errorf("write timeout must be positive, got %dms", t.WriteTimeoutMs)
+	case t.RangeTimeoutMs <= 0:
+		return fmt.Errorf("range timeout must be positive, got %dms", t.RangeTimeoutMs)
+	}
+	return nil
+}
+
+// Durations returns the read, write and range timeouts, so clients can set
+// their own timeouts slightly above them.
+func (t *QueryTimeouts) Durations() (read, write, rng time.Duration) {
+	return time.Duration(t.ReadTimeoutMs) * time.Millisecond,
+		time.Duration(t.WriteTimeoutMs) * time.Millisecond,
+		time.Duration(t.RangeTimeoutMs) * time.Millisecond
+}
+
+type ScrapeConfig struct {
+	URL         string `json:"url"`
+	JobName     string `json:"jobName"`
+	BearerToken string `json:"bearerToken"`
+}
+
+// String implements fmt.Stringer, it masks the bearer token.
+func (s ScrapeConfig) String() string {
+	return fmt.Sprintf("{URL:%s JobName:%s BearerToken:***}", s.URL, s.JobName)
+}
+
+type PendingDeletion struct {
+	ClusterID        int64  `json:"clusterId"`
+	ClusterName      string `json:"clusterName"`
+	DeletedAt        string `json:"deletedAt"`
+	RecoverableUntil string `json:"recoverableUntil"`
+}
+
+type ConfigValue struct {
+	Default string `json:"default"`
+	Current string `json:"current"`
+}
+
+type ConfigParameter struct {
+	Name string `json:"name"`
+	ConfigValue
+}
+
+type BackupStorage struct {
+	Location    string `json:"location"`
+	SizeBytes   int64  `json:"sizeBytes"`
+	ObjectCount int64  `json:"objectCount"`
+}
+
+func (b *BackupStorage) UnmarshalJSON(p []byte) error {
+	var v struct {
+		Location    string      `json:"location"`
+		SizeBytes   json.Number `json:"sizeBytes"`
+		ObjectCount json.Number `json:"objectCount"`
+	}
+
+	if err := json.Unmarshal(p, &v); err != nil {
+		return err
+	}
+
+	size, err := numberInt(v.SizeBytes)
+	if err != nil {
+		return fmt.Errorf("invalid sizeBytes: %w", err)
+	}
+
+	count, err := numberInt(v.ObjectCount)
+	if err != nil {
+		return fmt.Errorf("invalid objectCount: %w", err)
+	}
+
+	*b = BackupStorage{
+		Location:    v.Location,
+		SizeBytes:   size,
+		ObjectCount: count,
+	}
+
+	return nil
+}
+
+type SupportPlan struct {
+	Tier            string   `json:"tier"`
+	ResponseTimeSLA string   `json:"responseTimeSla"`
+	ContactChannels []string `json:"contactChannels"`
+}
+
+type RetentionPolicy struct {
+	Name          string `json:"name"`
+	RetentionDays int    `json:"retentionDays"`
+}
+
+type BackupSchedule struct {
+	Interval      string `json:"interval"`
+	StartTime     string `json:"startTime"`
+	RetentionDays int    `json:"retentionDays"`
+}
+
+type HealthSignals struct {
+	CompactionBacklog int64 `json:"compactionBacklog"`
+	PendingRepairs    int64 `json:"pendingRepairs"`
+	HintedHandoff     int64 `json:"hintedHandoff"`
+}
+
+type ResourceShare struct {
+	ID              int64  `json:"id,omitempty"`
+	TargetAccountID int64  `json:"targetAccountId"`
+	Permission      string `json:"permission"`
+}
+
+// Resource share permissions.
+const (
+	SharePermissionRead      = "READ"
+	SharePermissionReadWrite = "READ_WRITE"
+)
+
+func (s *ResourceShare) Validate() error {
+	switch {
+	case s.TargetAccountID <= 0:
+		return fmt.Errorf("target account ID must be positive, got %d", s.TargetAccountID)
+	case s.Permission != SharePermissionRead && s.Permission != SharePermissionReadWrite:
+		return fmt.Errorf("permission must be %q or %q, got %q", SharePermissionRead, SharePermissionReadWrite, s.Permission)
+	}
+	return nil
+}
+
+type NetworkResources struct {
+	VPCID         string   `json:"vpcId"`
+	SubnetIDs     []string `json:"subnetIds"`
+	RouteTableIDs []string `json:"routeTableIds"`
+}
+
+// CostEstimate is an estimated cost expressed in Currency. Currency is
+// empty if the billing currency of the account is not known.
+type CostEstimate struct {
+	Hourly   float64
+	Monthly  float64
+	Currency string
+}
+
+type AlertRoute struct {
+	Severity string   `json:"severity"`
+	Channels []string `json:"channels"`
+}
+
+type NodeReplacement struct {
+	NodeID     int64  `json:"nodeId"`
+	ReplacedAt string `json:"replacedAt"`
+	Reason     string `json:"reason"`
+}
+
+type KeyRotationStatus struct {
+	LastRotated   string `json:"lastRotated"`
+	NextScheduled string `json:"nextScheduled"`
+	InProgress    bool   `json:"inProgress"`
+}
+
+type BackupDestination struct {
+	Type   string `json:"type"`
+	Bucket string `json:"bucket"`
+	Region string `json:"region"`
+}
+
+// Backup destination types.
+const (
+	BackupDestinationS3  = "S3"
+	BackupDestinationGCS = "GCS"
+)
+
+var (
+	s3BucketRegexp  = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	gcsBucketRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,220}[a-z0-9]$`)
+	ipv4Regexp      = regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)
+)
+
+// Validate checks the bucket name against the naming rules of the storage
+// provider.
+func (d *BackupDestination) Validate() error {
+	switch d.Type {
+	case BackupDestinationS3:
+		switch {
+		case !s3BucketRegexp.MatchString(d.Bucket):
+			return fmt.Errorf("invalid S3 bucket name %q: must be 3-63 lowercase letters, digits, dots or hyphens", d.Bucket)
+		case strings.Contains(d.Bucket, ".."):
+			return fmt.Errorf("invalid S3 bucket name %q: must not contain two adjacent dots", d.Bucket)
+		case ipv4Regexp.MatchString(d.Bucket):
+			return fmt.Errorf("invalid S3 bucket name %q: must not be formatted as an IP address", d.Bucket)
+		}
+	case BackupDestinationGCS:
+		switch {
+		case !gcsBucketRegexp.MatchString(d.Bucket):
+			return fmt.Errorf("invalid GCS bucket name %q: must be 3-222 lowercase letters, digits, dots, hyphens or underscores", d.Bucket)
+		case !strings.Contains(d.Bucket, ".") && len(d.Bucket) > 63:
+			return fmt.Errorf("invalid GCS bucket name %q: names without dots must not be longer than 63 characters", d.Bucket)
+		case strings.HasPrefix(d.Bucket, "goog"):
+			return fmt.Errorf("invalid GCS bucket name %q: must not start with \"goog\"", d.Bucket)
+		}
+	default:
+		return fmt.Errorf("backup destination type must be %q or %q, got %q", BackupDestinationS3, BackupDestinationGCS, d.Type)
+	}
+	if d.Region == "" {
+		return errors.New("backup destination region must not be empty")
+	}
+	return nil
+}
+
+type ScalingEvent struct {
+	At           string `json:"at"`
+	FromNodes    int    `json:"fromNodes"`
+	ToNodes      int    `json:"toNodes"`
+	InstanceType string `json:"instanceType"`
+	Reason       string `json:"reason"`
+}
+
+type MetricsSink struct {
+	ID     int64             `json:"id,omitempty"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+}
+
+// Metrics sink types.
+const (
+	MetricsSinkDatadog    = "DATADOG"
+	MetricsSinkCloudWatch = "CLOUDWATCH"
+)
+
+// metricsSinkConfigKeys lists the configuration keys required by each
+// metrics sink type.
+var metricsSinkConfigKeys = map[string][]string{
+	MetricsSinkDatadog:    {"api_key", "site"},
+	MetricsSinkCloudWatch: {"region", "namespace", "role_arn"},
+}
+
+func (s *MetricsSink) Validate() error {
+	keys, ok := metricsSinkConfigKeys[s.Type]
+	if !ok {
+		return fmt.Errorf("metrics sink type must be %q or %q, got %q", MetricsSinkDatadog, MetricsSinkCloudWatch, s.Type)
+	}
+
+	var missing []string
+	for _, k := range keys {
+		if s.Config[k] == "" {
+			missing = append(missing, k)
+		}
+	}
+
+	if len(missing) != 0 {
+		return fmt.Errorf("%s metrics sink is missing required config: %s", s.Type, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+type PerfSnapshot struct {
+	ReadOpsPerSec  float64 `json:"readOpsPerSec"`
+	WriteOpsPerSec float64 `json:"writeOpsPerSec"`
+	P99ReadMs      float64 `json:"p99ReadMs"`
+	P99WriteMs     float64 `json:"p99WriteMs"`
+}
+
+type LoadShedding struct {
+	Active             bool    `json:"active"`
+	CPUThreshold       float64 `json:"cpuThreshold"`
+	QueueThreshold     int64   `json:"queueThreshold"`
+	ShedRequestsPerSec float64 `json:"shedRequestsPerSec"`
+}
+
+type UserAccount struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Default bool   `json:"default"`
+}
+
+type IdentityProvider struct {
+	ID      int64  `json:"id"`
+	Type    string `json:"type"`
+	Domain  string `json:"domain"`
+	Enabled bool   `json:"enabled"`
+}
+
+type CostAnomaly struct {
+	Date     string  `json:"date"`
+	Expected float64 `json:"expected"`
+	Actual   float64 `json:"actual"`
+	Severity string  `json:"severity"`
+}
+
+func (a *CostAnomaly) UnmarshalJSON(p []byte) error {
+	var v struct {
+		Date     string      `json:"date"`
+		Expected json.Number `json:"expected"`
+		Actual   json.Number `json:"actual"`
+		Severity string      `json:"severity"`
+	}
+
+	if err := json.Unmarshal(p, &v); err != nil {
+		return err
+	}
+
+	expected, err := numberFloat(v.Expected)
+	if err != nil {
+		return fmt.Errorf("invalid expected: %w", err)
+	}
+
+	actual, err := numberFloat(v.Actual)
+	if err != nil {
+		return fmt.Errorf("invalid actual: %w", err)
+	}
+
+	*a = CostAnomaly{
+		Date:     v.Date,
+		Expected: expected,
+		Actual:   actual,
+		Severity: v.Severity,
+	}
+
+	return nil
+}
+
+type ManagerTask struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Schedule string `json:"schedule"`
+	Status   string `json:"status"`
+}
+
+type DiskConfig struct {
+	Type           string `json:"type"`
+	SizeGB         int64  `json:"sizeGb"`
+	IOPS           int64  `json:"iops"`
+	ThroughputMBps int64  `json:"throughputMBps"`
+}
+
 type Stack struct {
 	RequestType        string         `json:"RequestType"`
 	RequestID          string         `json:"RequestId"`
 	ResourceProperties map[string]any `json:"ResourceProperties"`
 }
+
+// numberFloat converts n to float64, an empty number is treated as zero.
+func numberFloat(n json.Number) (float64, error) {
+	if n == "" {
+		return 0, nil
+	}
+	return n.Float64()
+}
+
+// numberInt converts n to int64, an empty number is treated as zero.
+// Integral values sent in floating point notation are accepted as well.
+func numberInt(n json.Number) (int64, error) {
+	if n == "" {
+		return 0, nil
+	}
+	if i, err := n.Int64(); err == nil {
+		return i, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("%s is not an integer", n)
+	}
+	return int64(f), nil
+}
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClusterDeploymentModel(t *testing.T) {
+	cases := map[string]struct {
+		cluster Cluster
+		want    string
+	}{
+		"dedicated": {
+			cluster: Cluster{},
+			want:    DeploymentDedicated,
+		},
+		"dedicated with pricing model": {
+			cluster: Cluster{PricingModel: 1},
+			want:    DeploymentDedicated,
+		},
+		"serverless": {
+			cluster: Cluster{Provisioning: "serverless"},
+			want:    DeploymentServerless,
+		},
+		"free tier": {
+			cluster: Cluster{FreeTier: &ExpirationTime{ExpirationSeconds: 3600}},
+			want:    DeploymentFreeTier,
+		},
+		"free tier serverless": {
+			cluster: Cluster{Provisioning: "serverless", FreeTier: &ExpirationTime{}},
+			want:    DeploymentFreeTier,
+		},
+	}
+
+	for name, cas := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := cas.cluster.DeploymentModel(); got != cas.want {
+				t.Fatalf("DeploymentModel()=%q, want %q", got, cas.want)
+			}
+		})
+	}
+}
+
+func TestBackupDestinationValidate(t *testing.T) {
+	cases := map[string]struct {
+		dest    BackupDestination
+		wantErr bool
+	}{
+		"s3": {
+			dest: BackupDestination{Type: BackupDestinationS3, Bucket: "my-backups.prod", Region: "us-east-1"},
+		},
+		"s3 uppercase": {
+			dest:    BackupDestination{Type: BackupDestinationS3, Bucket: "My-Backups", Region: "us-east-1"},
+			wantErr: true,
+		},
+		"s3 underscore": {
+			dest:    BackupDestination{Type: BackupDestinationS3, Bucket: "my_backups", Region: "us-east-1"},
+			wantErr: true,
+		},
+		"s3 adjacent dots": {
+			dest:    BackupDestination{Type: BackupDestinationS3, Bucket: "my..backups", Region: "us-east-1"},
+			wantErr: true,
+		},
+		"s3 ip address": {
+			dest:    BackupDestination{Type: BackupDestinationS3, Bucket: "192.168.1.1", Region: "us-east-1"},
+			wantErr: true,
+		},
+		"gcs": {
+			dest: BackupDestination{Type: BackupDestinationGCS, Bucket: "my_backups", Region: "us-central1"},
+		},
+		"gcs goog prefix": {
+			dest:    BackupDestination{Type: BackupDestinationGCS, Bucket: "google-backups", Region: "us-central1"},
+			wantErr: true,
+		},
+		"too short": {
+			dest:    BackupDestination{Type: BackupDestinationGCS, Bucket: "ab", Region: "us-central1"},
+			wantErr: true,
+		},
+		"no region": {
+			dest:    BackupDestination{Type: BackupDestinationS3, Bucket: "my-backups"},
+			wantErr: true,
+		},
+		"unknown type": {
+			dest:    BackupDestination{Type: "AZURE", Bucket: "my-backups", Region: "eastus"},
+			wantErr: true,
+		},
+	}
+
+	for name, cas := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := cas.dest.Validate(); (err != nil) != cas.wantErr {
+				t.Fatalf("Validate()=%v, want error %t", err, cas.wantErr)
+			}
+		})
+	}
+}
+
+func TestMetricsSinkValidate(t *testing.T) {
+	cases := map[string]struct {
+		sink    MetricsSink
+		wantErr bool
+	}{
+		"datadog": {
+			sink: MetricsSink{Type: MetricsSinkDatadog, Config: map[string]string{"api_key": "k", "site": "datadoghq.eu"}},
+		},
+		"datadog without api key": {
+			sink:    MetricsSink{Type: MetricsSinkDatadog, Config: map[string]string{"site": "datadoghq.eu"}},
+			wantErr: true,
+		},
+		"cloudwatch": {
+			sink: MetricsSink{Type: MetricsSinkCloudWatch, Config: map[string]string{
+				"region":    "us-east-1",
+				"namespace": "Scylla",
+				"role_arn":  "arn:aws:iam::123456789012:role/metrics",
+			}},
+		},
+		"cloudwatch without role": {
+			sink:    MetricsSink{Type: MetricsSinkCloudWatch, Config: map[string]string{"region": "us-east-1", "namespace": "Scylla"}},
+			wantErr: true,
+		},
+		"unknown type": {
+			sink:    MetricsSink{Type: "PROMETHEUS"},
+			wantErr: true,
+		},
+	}
+
+	for name, cas := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := cas.sink.Validate(); (err != nil) != cas.wantErr {
+				t.Fatalf("Validate()=%v, want error %t", err, cas.wantErr)
+			}
+		})
+	}
+}
+
+func TestClusterCreateRequestValidate(t *testing.T) {
+	valid := ClusterCreateRequest{
+		ClusterName:       "test",
+		CloudProviderID:   1,
+		RegionID:          1,
+		InstanceID:        10,
+		NumberOfNodes:     3,
+		ReplicationFactor: 3,
+	}
+
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate()=%+v", err)
+	}
+
+	invalid := valid
+	invalid.RegionID = 0
+	invalid.NumberOfNodes = 0
+
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatal("want error, got nil")
+	}
+	if want := "missing: region ID, number of nodes"; !strings.HasSuffix(err.Error(), want) {
+		t.Fatalf("want error ending with %q, got %q", want, err)
+	}
+}
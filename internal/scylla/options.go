@@ -0,0 +1,111 @@
+package scylla
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/eapache/go-resiliency/retrier"
+
+	v2scylla "github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/v2"
+)
+
+// WithEndpoint sets the URL of the API.
+func WithEndpoint(u *url.URL) func(*Client) {
+	return func(c *Client) {
+		c.Endpoint = u
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with API requests.
+func WithUserAgent(s string) func(*Client) {
+	return func(c *Client) {
+		c.Headers.Set("User-Agent", s)
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to call the API.
+func WithHTTPClient(hc *http.Client) func(*Client) {
+	return func(c *Client) {
+		c.HTTPClient = hc
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client used to call the API.
+// It applies to the client set with a preceding WithHTTPClient, if any.
+func WithTimeout(d time.Duration) func(*Client) {
+	return func(c *Client) {
+		hc := *c.HTTPClient
+		hc.Timeout = d
+		c.HTTPClient = &hc
+	}
+}
+
+// WithRetry retries a failed request up to maxRetries times, waiting for
+// backoff before the first retry and twice as long before each next one.
+// A maxRetries of 0 disables retries. It applies to the V2 client as well.
+func WithRetry(maxRetries int, backoff time.Duration) func(*Client) {
+	return func(c *Client) {
+		c.Retry = retrier.New(retrier.ExponentialBackoff(max(maxRetries, 0), backoff), DefaultClassifier)
+
+		if c.V2 != nil {
+			v2scylla.WithRetryPolicy(c.Retry)(c.V2)
+		}
+	}
+}
+
+// WithAccountID sets the account used in requests to the API, instead of
+// resolving the default account of the token owner.
+func WithAccountID(id int64) func(*Client) {
+	return func(c *Client) {
+		c.AccountID = id
+	}
+}
+
+// WithProxy routes the API requests through the proxy at u, instead of the
+// one configured with the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment
+// variables.
+func WithProxy(u *url.URL) func(*Client) {
+	return func(c *Client) {
+		withTransport(c, func(tr *http.Transport) {
+			tr.Proxy = http.ProxyURL(u)
+		})
+	}
+}
+
+// WithRootCAs sets the certificate authorities used to verify the API
+// server, e.g. to trust a corporate TLS inspecting proxy.
+func WithRootCAs(pool *x509.CertPool) func(*Client) {
+	return func(c *Client) {
+		withTransport(c, func(tr *http.Transport) {
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{}
+			}
+			tr.TLSClientConfig.RootCAs = pool
+		})
+	}
+}
+
+// withTransport changes a copy of the HTTP transport of the client, which
+// is then used by both the client and its V2 client. A transport set with
+// WithHTTPClient that is not an *http.Transport is replaced by a copy of
+// the default one.
+func withTransport(c *Client, fn func(*http.Transport)) {
+	tr, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		tr = http.DefaultTransport.(*http.Transport)
+	}
+
+	tr = tr.Clone()
+	fn(tr)
+
+	hc := *c.HTTPClient
+	hc.Transport = tr
+	c.HTTPClient = &hc
+
+	if c.V2 != nil {
+		v2scylla.WithTransport(tr)(c.V2)
+	}
+}
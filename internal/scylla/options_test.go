@@ -0,0 +1,190 @@
+package scylla
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+
+	"github.com/eapache/go-resiliency/retrier"
+)
+
+type countingTransport struct {
+	n int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.n++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestClientOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.Clusters{})
+	}))
+	defer srv.Close()
+
+	tr := &countingTransport{}
+
+	c, err := NewClient(context.Background(), srv.URL, "token", "test", false,
+		WithHTTPClient(&http.Client{Transport: tr}),
+		WithTimeout(5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("NewClient()=%+v", err)
+	}
+
+	if c.HTTPClient.Timeout != 5*time.Second {
+		t.Fatalf("want timeout 5s, got %s", c.HTTPClient.Timeout)
+	}
+
+	if _, err := c.ListClusters(context.Background()); err != nil {
+		t.Fatalf("ListClusters()=%+v", err)
+	}
+
+	if tr.n != 1 {
+		t.Fatalf("want request sent through the custom transport, got %d round trips", tr.n)
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	var calls int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeError(w, http.StatusServiceUnavailable, "Service Unavailable")
+	}))
+	defer srv.Close()
+
+	cases := map[int]int{
+		0: 1, // retries disabled, the request is sent once
+		2: 3,
+	}
+
+	for retries, want := range cases {
+		calls = 0
+
+		c, err := NewClient(context.Background(), srv.URL, "token", "test", false,
+			WithRetry(retries, time.Millisecond),
+		)
+		if err != nil {
+			t.Fatalf("NewClient()=%+v", err)
+		}
+
+		if _, err := c.ListClusters(context.Background()); err == nil {
+			t.Fatal("want error, got nil")
+		}
+
+		if calls != want {
+			t.Fatalf("WithRetry(%d): want %d calls, got %d", retries, want, calls)
+		}
+	}
+}
+
+func TestWithAccountID(t *testing.T) {
+	var defaultCalls int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /deployment/scylla-versions", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ScyllaVersions{})
+	})
+	mux.HandleFunc("GET /deployment/cloud-providers", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.CloudProviders{})
+	})
+	mux.HandleFunc("GET /account/default", func(w http.ResponseWriter, r *http.Request) {
+		defaultCalls++
+		writeError(w, http.StatusInternalServerError, "Internal Server Error")
+	})
+	mux.HandleFunc("GET /account", serveFixture(`{
+		"data": [{"id": 1, "name": "main", "default": true}, {"id": 7, "name": "staging"}]
+	}`))
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, err := NewClient(context.Background(), srv.URL, "token", "test", true, WithAccountID(7))
+	if err != nil {
+		t.Fatalf("NewClient()=%+v", err)
+	}
+
+	if c.AccountID != 7 {
+		t.Fatalf("want account ID 7, got %d", c.AccountID)
+	}
+	if defaultCalls != 0 {
+		t.Fatalf("want default account not requested, got %d calls", defaultCalls)
+	}
+
+	accounts, err := c.ListAccounts(context.Background())
+	if err != nil {
+		t.Fatalf("ListAccounts()=%+v", err)
+	}
+
+	want := []model.UserAccount{{ID: 1, Name: "main", Default: true}, {ID: 7, Name: "staging"}}
+	if !reflect.DeepEqual(accounts, want) {
+		t.Fatalf("want %+v, got %+v", want, accounts)
+	}
+}
+
+func TestWithRootCAs(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.Clusters{})
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(context.Background(), srv.URL, "token", "test", false)
+	if err != nil {
+		t.Fatalf("NewClient()=%+v", err)
+	}
+	c.Retry = retrier.New(nil, DefaultClassifier)
+
+	if _, err := c.ListClusters(context.Background()); err == nil {
+		t.Fatal("want certificate verification error without the test CA")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	c, err = NewClient(context.Background(), srv.URL, "token", "test", false, WithRootCAs(pool))
+	if err != nil {
+		t.Fatalf("NewClient()=%+v", err)
+	}
+
+	if _, err := c.ListClusters(context.Background()); err != nil {
+		t.Fatalf("ListClusters()=%+v", err)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied []string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		writeData(w, model.Clusters{})
+	}))
+	defer proxy.Close()
+
+	u, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatalf("Parse()=%+v", err)
+	}
+
+	c, err := NewClient(context.Background(), "http://api.scylla.invalid", "token", "test", false, WithProxy(u))
+	if err != nil {
+		t.Fatalf("NewClient()=%+v", err)
+	}
+
+	if _, err := c.ListClusters(context.Background()); err != nil {
+		t.Fatalf("ListClusters()=%+v", err)
+	}
+
+	if len(proxied) != 1 || !strings.HasPrefix(proxied[0], "http://api.scylla.invalid/account/") {
+		t.Fatalf("want request sent through the proxy, got %q", proxied)
+	}
+}
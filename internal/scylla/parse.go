@@ -42,6 +42,19 @@ var (
 	}
 )
 
+//go:embed durations.txt
+var durations []byte
+
+var (
+	durationsDelim = "\t"
+	durationsFunc  = func(k, v string) (string, string, error) {
+		if n, err := strconv.Atoi(v); err != nil || n <= 0 {
+			return "", "", fmt.Errorf("invalid %q duration: %q", k, v)
+		}
+		return k, v, nil
+	}
+)
+
 type mapFunc func(k, v string) (string, string, error)
 
 func parse(p []byte, delim string, fn mapFunc) (map[string]string, error) {
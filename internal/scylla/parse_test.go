@@ -29,3 +29,16 @@ func TestParseCIDRBlocks(t *testing.T) {
 
 	t.Log(m)
 }
+
+func TestParseDurations(t *testing.T) {
+	m, err := parse(durations, durationsDelim, durationsFunc)
+	if err != nil {
+		t.Fatalf("parse()=%+v", err)
+	}
+
+	for _, key := range []string{"base", "default"} {
+		if _, ok := m[key]; !ok {
+			t.Fatalf("want %q key, got %v", key, m)
+		}
+	}
+}
@@ -0,0 +1,407 @@
+package scylla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+// FullClusterSpec describes the desired state of a cluster together with
+// its allowlist, VPC peerings and tags.
+type FullClusterSpec struct {
+	// ClusterID identifies the cluster to update. When zero, the cluster
+	// is looked up by name and created if it does not exist.
+	ClusterID int64
+
+	// Cluster holds the parameters used to create the cluster.
+	Cluster model.ClusterCreateRequest
+
+	// AllowedIPs is the complete list of allowed addresses, rules not on
+	// the list are removed. A nil slice leaves the allowlist unmanaged.
+	AllowedIPs []string
+
+	// VPCPeerings lists the peerings the cluster must have. Peerings are
+	// matched by VPC and region, and are never removed.
+	VPCPeerings []model.VPCPeeringRequest
+
+	// Tags replaces cluster tags. A nil map leaves the tags unmanaged.
+	Tags map[string]string
+}
+
+func (s *FullClusterSpec) validate() error {
+	if s.ClusterID == 0 && s.Cluster.ClusterName == "" {
+		return errors.New("either cluster ID or cluster name is required")
+	}
+
+	for _, addr := range s.AllowedIPs {
+		if net.ParseIP(addr) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(addr); err != nil {
+			return fmt.Errorf("invalid allowed address %q", addr)
+		}
+	}
+
+	if err := validateTags(s.Tags); err != nil {
+		return err
+	}
+
+	for i := range s.VPCPeerings {
+		if s.VPCPeerings[i].VPC == "" {
+			return fmt.Errorf("VPC peering #%d: VPC ID is required", i)
+		}
+	}
+
+	return nil
+}
+
+// PlanIssue describes a problem with one of the specs checked by
+// ValidatePlan.
+type PlanIssue struct {
+	// Spec is the index of the offending spec.
+	Spec int
+
+	Message string
+}
+
+func (i PlanIssue) String() string {
+	return fmt.Sprintf("spec #%d: %s", i.Spec, i.Message)
+}
+
+// ValidatePlan checks a batch of specs before any of them is applied.
+// Besides validating each spec on its own, it reports cluster names and
+// CIDR blocks used by more than one new cluster, and allowlists that do
+// not fit within the rule limit of an existing cluster. All issues found
+// are returned; the error is reserved for failures to query the API.
+func (c *Client) ValidatePlan(ctx context.Context, specs []FullClusterSpec) ([]PlanIssue, error) {
+	var (
+		issues []PlanIssue
+		names  = make(map[string]int)
+		cidrs  = make(map[int]netip.Prefix)
+	)
+
+	for i := range specs {
+		spec := &specs[i]
+
+		if err := spec.validate(); err != nil {
+			issues = append(issues, PlanIssue{Spec: i, Message: err.Error()})
+		}
+
+		if spec.ClusterID != 0 {
+			if spec.AllowedIPs == nil {
+				continue
+			}
+
+			limit, err := c.GetAllowlistRuleLimit(ctx, spec.ClusterID)
+			if err != nil {
+				return nil, fmt.Errorf("error reading allowlist rule limit of cluster %d: %w", spec.ClusterID, err)
+			}
+
+			if limit > 0 && len(spec.AllowedIPs) > limit {
+				issues = append(issues, PlanIssue{Spec: i, Message: fmt.Sprintf("%d allowed addresses exceed the limit of %d", len(spec.AllowedIPs), limit)})
+			}
+
+			continue
+		}
+
+		if name := spec.Cluster.ClusterName; name != "" {
+			if j, ok := names[name]; ok {
+				issues = append(issues, PlanIssue{Spec: i, Message: fmt.Sprintf("cluster name %q is also used by spec #%d", name, j)})
+			} else {
+				names[name] = i
+			}
+		}
+
+		if spec.Cluster.CidrBlock == "" {
+			continue
+		}
+
+		p, err := netip.ParsePrefix(spec.Cluster.CidrBlock)
+		if err != nil {
+			issues = append(issues, PlanIssue{Spec: i, Message: fmt.Sprintf("invalid CIDR %q", spec.Cluster.CidrBlock)})
+			continue
+		}
+
+		for j := 0; j < i; j++ {
+			if q, ok := cidrs[j]; ok && p.Overlaps(q) {
+				issues = append(issues, PlanIssue{Spec: i, Message: fmt.Sprintf("CIDR %s overlaps %s of spec #%d", p, q, j)})
+			}
+		}
+
+		cidrs[i] = p.Masked()
+	}
+
+	return issues, nil
+}
+
+// rollback holds the undo steps for the changes made so far.
+type rollback []func(context.Context) error
+
+func (rb *rollback) add(fn func(context.Context) error) {
+	*rb = append(*rb, fn)
+}
+
+// run undoes recorded changes in reverse order. The returned error wraps
+// err and reports any step that could not be undone.
+func (rb rollback) run(ctx context.Context, err error) error {
+	ctx = context.WithoutCancel(ctx)
+
+	for i := len(rb) - 1; i >= 0; i-- {
+		if e := rb[i](ctx); e != nil {
+			err = fmt.Errorf("%w (rollback failed: %s)", err, e)
+		}
+	}
+
+	return err
+}
+
+// ApplyClusterSpec creates or updates the cluster described by spec,
+// reconciling its allowlist, VPC peerings and tags. If any step fails,
+// the changes made by this call are rolled back where possible, which
+// includes deleting a cluster that was created by it.
+func (c *Client) ApplyClusterSpec(ctx context.Context, spec FullClusterSpec) (*model.Cluster, error) {
+	if err := spec.validate(); err != nil {
+		return nil, fmt.Errorf("invalid cluster spec: %w", err)
+	}
+
+	clusterID := spec.ClusterID
+
+	if clusterID == 0 {
+		cluster, err := c.clusterByName(ctx, spec.Cluster.ClusterName)
+		if err != nil {
+			return nil, err
+		}
+		if cluster != nil {
+			clusterID = cluster.ID
+		}
+	}
+
+	var rb rollback
+
+	if clusterID == 0 {
+		req := spec.Cluster
+		req.AllowedIPs = spec.AllowedIPs
+		if req.Tags == nil {
+			req.Tags = spec.Tags
+		}
+
+		cr, err := c.CreateCluster(ctx, &req)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cluster: %w", err)
+		}
+
+		clusterID = cr.ClusterID
+
+		rb.add(func(ctx context.Context) error {
+			_, err := c.DeleteCluster(ctx, clusterID, req.ClusterName)
+			return err
+		})
+
+		if _, err := c.WaitForClusterRequest(ctx, cr.ID, requestPollInterval); err != nil {
+			return nil, rb.run(ctx, fmt.Errorf("error waiting for cluster: %w", err))
+		}
+	}
+
+	if err := c.applyVPCPeerings(ctx, clusterID, spec.VPCPeerings, &rb); err != nil {
+		return nil, rb.run(ctx, err)
+	}
+
+	if spec.Tags != nil {
+		if err := c.applyTags(ctx, clusterID, spec.Tags, &rb); err != nil {
+			return nil, rb.run(ctx, err)
+		}
+	}
+
+	if spec.AllowedIPs != nil {
+		if err := c.applyAllowlist(ctx, clusterID, spec.AllowedIPs, &rb); err != nil {
+			return nil, rb.run(ctx, err)
+		}
+	}
+
+	cluster, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster: %w", err)
+	}
+
+	return cluster, nil
+}
+
+func (c *Client) clusterByName(ctx context.Context, name string) (*model.Cluster, error) {
+	clusters, err := c.ListClusters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing clusters: %w", err)
+	}
+
+	for i := range clusters {
+		if clusters[i].ClusterName == name && !strings.EqualFold(clusters[i].Status, "DELETED") {
+			return &clusters[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) applyVPCPeerings(ctx context.Context, clusterID int64, want []model.VPCPeeringRequest, rb *rollback) error {
+	if len(want) == 0 {
+		return nil
+	}
+
+	have, err := c.ListClusterVPCPeerings(ctx, clusterID)
+	if err != nil {
+		return fmt.Errorf("error listing VPC peerings: %w", err)
+	}
+
+	for i := range want {
+		req := &want[i]
+
+		if hasVPCPeering(have, req) {
+			continue
+		}
+
+		p, err := c.CreateClusterVPCPeering(ctx, clusterID, req)
+		if err != nil {
+			return fmt.Errorf("error creating VPC peering for %q: %w", req.VPC, err)
+		}
+
+		rb.add(func(ctx context.Context) error {
+			return c.DeleteClusterVPCPeering(ctx, clusterID, p.ID)
+		})
+	}
+
+	return nil
+}
+
+func hasVPCPeering(peerings []model.VPCPeering, req *model.VPCPeeringRequest) bool {
+	for i := range peerings {
+		if peerings[i].VPCID == req.VPC && (req.RegionID == 0 || peerings[i].RegionID == req.RegionID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) applyTags(ctx context.Context, clusterID int64, want map[string]string, rb *rollback) error {
+	have, err := c.GetClusterTags(ctx, clusterID)
+	if err != nil {
+		return fmt.Errorf("error reading tags: %w", err)
+	}
+
+	if maps.Equal(have, want) {
+		return nil
+	}
+
+	if err := c.SetClusterTags(ctx, clusterID, want); err != nil {
+		return fmt.Errorf("error setting tags: %w", err)
+	}
+
+	rb.add(func(ctx context.Context) error {
+		return c.SetClusterTags(ctx, clusterID, have)
+	})
+
+	return nil
+}
+
+func (c *Client) applyAllowlist(ctx context.Context, clusterID int64, want []string, rb *rollback) error {
+	rules, err := c.ListAllowlistRules(ctx, clusterID)
+	if err != nil {
+		return fmt.Errorf("error listing allowlist rules: %w", err)
+	}
+
+	keep := make(map[string]bool, len(want))
+	for _, addr := range want {
+		keep[addr] = true
+	}
+
+	var missing []string
+	for _, addr := range want {
+		if ruleByAddress(rules, addr) == nil {
+			missing = append(missing, addr)
+		}
+	}
+
+	var stale []model.AllowedIP
+	for _, r := range rules {
+		if !keep[r.Address] {
+			stale = append(stale, r)
+		}
+	}
+
+	if len(missing) != 0 {
+		if err := c.validateAllowlistAddresses(ctx, clusterID, missing); err != nil {
+			return err
+		}
+
+		if err := c.checkAllowlistRuleLimit(ctx, clusterID, len(rules), len(rules)-len(stale)+len(missing)); err != nil {
+			return err
+		}
+	}
+
+	// Stale rules go first, so that a cluster at its limit can still
+	// have its rules replaced.
+	for _, r := range stale {
+		if err := c.DeleteAllowlistRule(ctx, clusterID, r.ID); err != nil {
+			return fmt.Errorf("error deleting allowlist rule for %q: %w", r.Address, err)
+		}
+
+		addr := r.Address
+		rb.add(func(ctx context.Context) error {
+			_, err := c.createAllowlistRule(ctx, clusterID, addr)
+			return err
+		})
+	}
+
+	for _, addr := range missing {
+		created, err := c.createAllowlistRule(ctx, clusterID, addr)
+		if err != nil {
+			return fmt.Errorf("error creating allowlist rule for %q: %w", addr, err)
+		}
+
+		if r := ruleByAddress(created, addr); r != nil {
+			ruleID := r.ID
+			rb.add(func(ctx context.Context) error {
+				return c.DeleteAllowlistRule(ctx, clusterID, ruleID)
+			})
+		}
+	}
+
+	return nil
+}
+
+// validateAllowlistAddresses checks the addresses the same way as
+// CreateAllowlistRule does, reading the cluster at most once.
+func (c *Client) validateAllowlistAddresses(ctx context.Context, clusterID int64, addresses []string) error {
+	var maxRange int64
+
+	if slices.ContainsFunc(addresses, func(addr string) bool { return strings.Contains(addr, "/") }) {
+		cluster, err := c.GetCluster(ctx, clusterID)
+		if err != nil {
+			return fmt.Errorf("error reading cluster: %w", err)
+		}
+
+		maxRange = cluster.MaxAllowedCIDRRange
+	}
+
+	for _, addr := range addresses {
+		if err := validateAllowlistAddress(addr, maxRange); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ruleByAddress(rules []model.AllowedIP, addr string) *model.AllowedIP {
+	for i := range rules {
+		if rules[i].Address == addr {
+			return &rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+package scylla
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+// fakeClusterAPI serves the subset of the API used by ApplyClusterSpec.
+type fakeClusterAPI struct {
+	mu       sync.Mutex
+	clusters []model.Cluster
+	rules    []model.AllowedIP
+	peerings []model.VPCPeering
+	tags     map[string]string
+	limit    int
+	nextID   int64
+	failTags bool
+	deleted  []int64
+}
+
+func (f *fakeClusterAPI) id() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *fakeClusterAPI) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	prefix := fmt.Sprintf("/account/%d", testAccountID)
+
+	mux.HandleFunc("GET "+prefix+"/clusters", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeData(w, model.Clusters{Clusters: f.clusters})
+	})
+	mux.HandleFunc("POST "+prefix+"/cluster", func(w http.ResponseWriter, r *http.Request) {
+		var req model.ClusterCreateRequest
+		readBody(t, r, &req)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.clusters = append(f.clusters, model.Cluster{ID: 42, ClusterName: req.ClusterName, Status: "ACTIVE"})
+		for _, addr := range req.AllowedIPs {
+			f.rules = append(f.rules, model.AllowedIP{ID: f.id(), ClusterID: 42, Address: addr})
+		}
+		writeData(w, map[string]int64{"requestId": 7})
+	})
+	mux.HandleFunc("GET "+prefix+"/cluster/request/7", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterRequest{ID: 7, ClusterID: 42, Status: "COMPLETED"})
+	})
+	mux.HandleFunc("GET "+prefix+"/cluster/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for _, cl := range f.clusters {
+			if strconv.FormatInt(cl.ID, 10) == r.PathValue("id") {
+				writeData(w, model.ClusterDetails{Cluster: cl})
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "040001")
+	})
+	mux.HandleFunc("POST "+prefix+"/cluster/{id}/delete", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.deleted = append(f.deleted, id)
+		writeData(w, model.ClusterRequest{ID: 8, ClusterID: id, Status: "QUEUED"})
+	})
+	mux.HandleFunc("GET "+prefix+"/cluster/{id}/network/firewall/allowed", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeData(w, f.rules)
+	})
+	mux.HandleFunc("POST "+prefix+"/cluster/{id}/network/firewall/allowed", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Address string `json:"ipAddress"`
+		}
+		readBody(t, r, &req)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.rules = append(f.rules, model.AllowedIP{ID: f.id(), Address: req.Address})
+		writeData(w, f.rules)
+	})
+	mux.HandleFunc("GET "+prefix+"/cluster/{id}/network/firewall/limit", func(w http.ResponseWriter, r *http.Request) {
+		if f.limit == 0 {
+			writeError(w, http.StatusNotFound, "040001")
+			return
+		}
+		writeData(w, map[string]int{"limit": f.limit})
+	})
+	mux.HandleFunc("DELETE "+prefix+"/cluster/{id}/network/firewall/allowed/{rule}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for i, rule := range f.rules {
+			if strconv.FormatInt(rule.ID, 10) == r.PathValue("rule") {
+				f.rules = append(f.rules[:i], f.rules[i+1:]...)
+				break
+			}
+		}
+		writeData(w, nil)
+	})
+	mux.HandleFunc("GET "+prefix+"/cluster/{id}/network/vpc/peer", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeData(w, f.peerings)
+	})
+	mux.HandleFunc("POST "+prefix+"/cluster/{id}/network/vpc/peer", func(w http.ResponseWriter, r *http.Request) {
+		var req model.VPCPeeringRequest
+		readBody(t, r, &req)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		p := model.VPCPeering{ID: f.id(), VPCID: req.VPC, RegionID: req.RegionID}
+		f.peerings = append(f.peerings, p)
+		writeData(w, map[string]int64{"id": p.ID})
+	})
+	mux.HandleFunc("GET "+prefix+"/cluster/{id}/network/vpc/peer/{peer}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for _, p := range f.peerings {
+			if strconv.FormatInt(p.ID, 10) == r.PathValue("peer") {
+				writeData(w, p)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "040001")
+	})
+	mux.HandleFunc("DELETE "+prefix+"/cluster/{id}/network/vpc/peer/{peer}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for i, p := range f.peerings {
+			if strconv.FormatInt(p.ID, 10) == r.PathValue("peer") {
+				f.peerings = append(f.peerings[:i], f.peerings[i+1:]...)
+				break
+			}
+		}
+		writeData(w, nil)
+	})
+	mux.HandleFunc("GET "+prefix+"/cluster/{id}/tags", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeData(w, model.ClusterTags{Tags: f.tags})
+	})
+	mux.HandleFunc("POST "+prefix+"/cluster/{id}/tags", func(w http.ResponseWriter, r *http.Request) {
+		var req model.ClusterTags
+		readBody(t, r, &req)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.failTags {
+			writeError(w, http.StatusBadRequest, "Bad Request")
+			return
+		}
+		f.tags = req.Tags
+		writeData(w, nil)
+	})
+
+	return mux
+}
+
+func TestApplyClusterSpecCreate(t *testing.T) {
+	requestPollInterval = time.Millisecond
+
+	f := &fakeClusterAPI{nextID: 100}
+	c := newTestClient(t, f.handler(t))
+
+	cluster, err := c.ApplyClusterSpec(context.Background(), FullClusterSpec{
+		Cluster:     testCreateRequest("test"),
+		AllowedIPs:  []string{"10.0.0.0/24"},
+		VPCPeerings: []model.VPCPeeringRequest{{VPC: "vpc-1", RegionID: 1}},
+		Tags:        map[string]string{"team": "db"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyClusterSpec()=%+v", err)
+	}
+
+	if cluster.ID != 42 {
+		t.Fatalf("want cluster ID %d, got %d", 42, cluster.ID)
+	}
+	if len(f.rules) != 1 || f.rules[0].Address != "10.0.0.0/24" {
+		t.Fatalf("unexpected allowlist rules: %+v", f.rules)
+	}
+	if len(f.peerings) != 1 || f.peerings[0].VPCID != "vpc-1" {
+		t.Fatalf("unexpected VPC peerings: %+v", f.peerings)
+	}
+	if f.tags["team"] != "db" {
+		t.Fatalf("unexpected tags: %+v", f.tags)
+	}
+}
+
+func TestApplyClusterSpecUpdate(t *testing.T) {
+	requestPollInterval = time.Millisecond
+
+	f := &fakeClusterAPI{
+		nextID:   100,
+		clusters: []model.Cluster{{ID: 42, ClusterName: "test", Status: "ACTIVE"}},
+		rules: []model.AllowedIP{
+			{ID: 1, Address: "10.0.0.0/24"},
+			{ID: 2, Address: "10.1.0.0/24"},
+		},
+		peerings: []model.VPCPeering{{ID: 3, VPCID: "vpc-1", RegionID: 1}},
+		tags:     map[string]string{"team": "db"},
+	}
+	c := newTestClient(t, f.handler(t))
+
+	_, err := c.ApplyClusterSpec(context.Background(), FullClusterSpec{
+		Cluster:     testCreateRequest("test"),
+		AllowedIPs:  []string{"10.0.0.0/24", "10.2.0.0/24"},
+		VPCPeerings: []model.VPCPeeringRequest{{VPC: "vpc-1", RegionID: 1}},
+		Tags:        map[string]string{"team": "db"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyClusterSpec()=%+v", err)
+	}
+
+	if len(f.rules) != 2 || f.rules[0].Address != "10.0.0.0/24" || f.rules[1].Address != "10.2.0.0/24" {
+		t.Fatalf("unexpected allowlist rules: %+v", f.rules)
+	}
+	if len(f.peerings) != 1 {
+		t.Fatalf("unexpected VPC peerings: %+v", f.peerings)
+	}
+	if len(f.deleted) != 0 {
+		t.Fatalf("unexpected deleted clusters: %v", f.deleted)
+	}
+}
+
+func TestApplyClusterSpecAllowlistLimit(t *testing.T) {
+	f := &fakeClusterAPI{
+		nextID:   100,
+		limit:    2,
+		clusters: []model.Cluster{{ID: 42, ClusterName: "test", Status: "ACTIVE"}},
+		rules: []model.AllowedIP{
+			{ID: 1, Address: "10.0.0.1"},
+			{ID: 2, Address: "10.0.0.2"},
+		},
+	}
+	c := newTestClient(t, f.handler(t))
+
+	_, err := c.ApplyClusterSpec(context.Background(), FullClusterSpec{
+		ClusterID:  42,
+		Cluster:    testCreateRequest("test"),
+		AllowedIPs: []string{"10.0.0.1", "10.0.0.3", "10.0.0.4"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "has 2 rule(s), the change leaves 3 which exceeds the limit of 2") {
+		t.Fatalf("want limit exceeded error, got %+v", err)
+	}
+	if len(f.rules) != 2 {
+		t.Fatalf("want allowlist rules unchanged, got %+v", f.rules)
+	}
+
+	// Replacing every rule of a cluster at its limit fits.
+	_, err = c.ApplyClusterSpec(context.Background(), FullClusterSpec{
+		ClusterID:  42,
+		Cluster:    testCreateRequest("test"),
+		AllowedIPs: []string{"10.0.0.3", "10.0.0.4"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyClusterSpec()=%+v", err)
+	}
+
+	var got []string
+	for _, rule := range f.rules {
+		got = append(got, rule.Address)
+	}
+	if want := []string{"10.0.0.3", "10.0.0.4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got allowlist rules %q, want %q", got, want)
+	}
+}
+
+func TestApplyClusterSpecRollback(t *testing.T) {
+	requestPollInterval = time.Millisecond
+
+	f := &fakeClusterAPI{nextID: 100, failTags: true}
+	c := newTestClient(t, f.handler(t))
+
+	_, err := c.ApplyClusterSpec(context.Background(), FullClusterSpec{
+		Cluster:     testCreateRequest("test"),
+		VPCPeerings: []model.VPCPeeringRequest{{VPC: "vpc-1"}},
+		Tags:        map[string]string{"team": "db"},
+	})
+	if err == nil {
+		t.Fatal("want error, got nil")
+	}
+
+	if len(f.peerings) != 0 {
+		t.Fatalf("want VPC peerings rolled back, got %+v", f.peerings)
+	}
+	if len(f.deleted) != 1 || f.deleted[0] != 42 {
+		t.Fatalf("want cluster 42 deleted, got %v", f.deleted)
+	}
+}
+
+func TestValidatePlan(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/3/network/firewall/limit", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]int{"limit": 1})
+	})
+
+	c := newTestClient(t, mux)
+
+	first := testCreateRequest("orders")
+	first.CidrBlock = "172.31.0.0/16"
+
+	second := testCreateRequest("orders")
+	second.CidrBlock = "172.31.128.0/24"
+
+	specs := []FullClusterSpec{
+		{Cluster: first},
+		{Cluster: second},
+		{ClusterID: 3, AllowedIPs: []string{"10.0.0.1", "10.0.0.2"}},
+	}
+
+	issues, err := c.ValidatePlan(context.Background(), specs)
+	if err != nil {
+		t.Fatalf("ValidatePlan()=%+v", err)
+	}
+
+	want := []string{
+		`spec #1: cluster name "orders" is also used by spec #0`,
+		"spec #1: CIDR 172.31.128.0/24 overlaps 172.31.0.0/16 of spec #0",
+		"spec #2: 2 allowed addresses exceed the limit of 1",
+	}
+
+	got := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got issues %q, want %q", got, want)
+	}
+}
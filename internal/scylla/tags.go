@@ -0,0 +1,90 @@
+package scylla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+
+	// bulkConcurrency limits the number of concurrent API calls made
+	// by the bulk operations.
+	bulkConcurrency = 4
+)
+
+// acquire takes a slot of the semaphore, unless ctx is done first.
+func acquire(ctx context.Context, sem chan struct{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func validateTags(tags map[string]string) error {
+	for k, v := range tags {
+		switch {
+		case k == "":
+			return errors.New("tag key must not be empty")
+		case utf8.RuneCountInString(k) > maxTagKeyLength:
+			return fmt.Errorf("tag key %q is longer than %d characters", k, maxTagKeyLength)
+		case utf8.RuneCountInString(v) > maxTagValueLength:
+			return fmt.Errorf("value of tag %q is longer than %d characters", k, maxTagValueLength)
+		}
+	}
+	return nil
+}
+
+// BulkSetTags sets tags on each of the given clusters. Tags are validated
+// once, before any cluster is modified. Failures of individual clusters are
+// reported in the returned map, keyed by cluster ID. If the context is done
+// before all clusters are started, the context error is returned along with
+// the failures so far.
+func (c *Client) BulkSetTags(ctx context.Context, clusterIDs []int64, tags map[string]string) (map[int64]error, error) {
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, bulkConcurrency)
+		errs   = make(map[int64]error)
+		ctxErr error
+	)
+
+	for _, id := range clusterIDs {
+		if ctxErr = acquire(ctx, sem); ctxErr != nil {
+			break
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := c.SetClusterTags(ctx, id, tags); err != nil {
+				mu.Lock()
+				errs[id] = err
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return errs, ctxErr
+}
@@ -0,0 +1,76 @@
+package scylla
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+func TestBulkSetTags(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /account/1/cluster/{id}/tags", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "2" {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		writeData(w, nil)
+	})
+
+	c := newTestClient(t, mux)
+
+	errs, err := c.BulkSetTags(context.Background(), []int64{1, 2, 3}, map[string]string{"team": "db"})
+	if err != nil {
+		t.Fatalf("BulkSetTags()=%+v", err)
+	}
+
+	if len(errs) != 1 || errs[2] == nil {
+		t.Fatalf("want error for cluster 2 only, got %+v", errs)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs, err = c.BulkSetTags(ctx, []int64{1, 3}, map[string]string{"team": "db"})
+	if !errors.Is(err, context.Canceled) || len(errs) != 0 {
+		t.Fatalf("want context.Canceled, got %+v, %+v", errs, err)
+	}
+
+	if _, err := c.BulkSetTags(context.Background(), []int64{1}, map[string]string{"": "db"}); err == nil {
+		t.Fatal("want error for empty tag key, got nil")
+	}
+
+	if _, err := c.BulkSetTags(context.Background(), []int64{1}, map[string]string{"team": strings.Repeat("x", 300)}); err == nil {
+		t.Fatal("want error for too long tag value, got nil")
+	}
+}
+
+func TestGetEffectiveTags(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/tags/default", serveFixture(`{
+		"data": {"tags": {"team": "platform", "cost-center": "42"}}
+	}`))
+	mux.HandleFunc("GET /account/1/cluster/1/tags", serveFixture(`{
+		"data": {"tags": {"team": "db", "env": "prod"}}
+	}`))
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GetEffectiveTags(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetEffectiveTags()=%+v", err)
+	}
+
+	want := map[string]model.EffectiveTag{
+		"team":        {Value: "db", Source: model.TagSourceCluster},
+		"env":         {Value: "prod", Source: model.TagSourceCluster},
+		"cost-center": {Value: "42", Source: model.TagSourceAccount},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+}
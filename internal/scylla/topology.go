@@ -0,0 +1,66 @@
+package scylla
+
+import (
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+// DCChange pairs a desired datacenter with the existing one it maps to.
+// Desired is nil for a datacenter to remove, Actual is nil for one to add.
+type DCChange struct {
+	Desired *model.DatacenterRequest
+	Actual  *model.Datacenter
+}
+
+// DiffDataCenters computes the changes needed to turn the actual datacenters
+// of a cluster into the desired ones. Datacenters are matched by region, so
+// reordering them yields no changes. A matched datacenter is modified only
+// if one of the desired fields differs; fields left unset in the desired
+// datacenter and server-assigned fields, such as ID or status, are ignored.
+func DiffDataCenters(desired []model.DatacenterRequest, actual []model.Datacenter) (add, remove, modify []DCChange) {
+	matched := make(map[int64]bool, len(actual))
+
+	for i := range desired {
+		d := &desired[i]
+
+		a := datacenterByRegion(actual, d.RegionID)
+		if a == nil {
+			add = append(add, DCChange{Desired: d})
+			continue
+		}
+
+		matched[a.ID] = true
+
+		if datacenterDiffers(d, a) {
+			modify = append(modify, DCChange{Desired: d, Actual: a})
+		}
+	}
+
+	for i := range actual {
+		if !matched[actual[i].ID] {
+			remove = append(remove, DCChange{Actual: &actual[i]})
+		}
+	}
+
+	return add, remove, modify
+}
+
+func datacenterByRegion(dcs []model.Datacenter, regionID int64) *model.Datacenter {
+	for i := range dcs {
+		if dcs[i].RegionID == regionID {
+			return &dcs[i]
+		}
+	}
+	return nil
+}
+
+func datacenterDiffers(d *model.DatacenterRequest, a *model.Datacenter) bool {
+	switch {
+	case d.InstanceID != 0 && d.InstanceID != a.InstanceID:
+		return true
+	case d.ReplicationFactor != 0 && d.ReplicationFactor != a.ReplicationFactor:
+		return true
+	case d.CIDRBlock != "" && d.CIDRBlock != a.CIDRBlock:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package scylla
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+func TestDiffDataCenters(t *testing.T) {
+	actual := []model.Datacenter{
+		{ID: 11, Name: "AWS_US_EAST_1", Status: "ACTIVE", RegionID: 1, InstanceID: 10, ReplicationFactor: 3, CIDRBlock: "172.31.0.0/16"},
+		{ID: 12, Name: "AWS_EU_WEST_1", Status: "ACTIVE", RegionID: 2, InstanceID: 10, ReplicationFactor: 3, CIDRBlock: "172.32.0.0/16"},
+	}
+
+	cases := []struct {
+		name    string
+		desired []model.DatacenterRequest
+		add     []int64 // region IDs
+		remove  []int64
+		modify  []int64
+	}{
+		{
+			name: "unchanged in different order",
+			desired: []model.DatacenterRequest{
+				{RegionID: 2, InstanceID: 10, ReplicationFactor: 3},
+				{RegionID: 1, InstanceID: 10, ReplicationFactor: 3, CIDRBlock: "172.31.0.0/16"},
+			},
+		},
+		{
+			name: "add a region",
+			desired: []model.DatacenterRequest{
+				{RegionID: 1, InstanceID: 10, ReplicationFactor: 3},
+				{RegionID: 2, InstanceID: 10, ReplicationFactor: 3},
+				{RegionID: 3, InstanceID: 10, ReplicationFactor: 3, CIDRBlock: "172.33.0.0/16"},
+			},
+			add: []int64{3},
+		},
+		{
+			name: "remove a region",
+			desired: []model.DatacenterRequest{
+				{RegionID: 1, InstanceID: 10, ReplicationFactor: 3},
+			},
+			remove: []int64{2},
+		},
+		{
+			name: "resize a datacenter",
+			desired: []model.DatacenterRequest{
+				{RegionID: 1, InstanceID: 10, ReplicationFactor: 3},
+				{RegionID: 2, InstanceID: 20, ReplicationFactor: 3},
+			},
+			modify: []int64{2},
+		},
+	}
+
+	for _, cas := range cases {
+		t.Run(cas.name, func(t *testing.T) {
+			add, remove, modify := DiffDataCenters(cas.desired, actual)
+
+			if got := changeRegions(add); !reflect.DeepEqual(got, cas.add) {
+				t.Errorf("add=%v, want %v", got, cas.add)
+			}
+			if got := changeRegions(remove); !reflect.DeepEqual(got, cas.remove) {
+				t.Errorf("remove=%v, want %v", got, cas.remove)
+			}
+			if got := changeRegions(modify); !reflect.DeepEqual(got, cas.modify) {
+				t.Errorf("modify=%v, want %v", got, cas.modify)
+			}
+		})
+	}
+}
+
+func changeRegions(changes []DCChange) (regions []int64) {
+	for _, c := range changes {
+		if c.Desired != nil {
+			regions = append(regions, c.Desired.RegionID)
+		} else {
+			regions = append(regions, c.Actual.RegionID)
+		}
+	}
+	return regions
+}
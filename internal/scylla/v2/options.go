@@ -36,6 +36,12 @@ func WithUserAgent(s string) func(*Client) {
 	}
 }
 
+func WithTransport(rt http.RoundTripper) func(*Client) {
+	return func(c *Client) {
+		c.client.Transport = rt
+	}
+}
+
 func WithGlobalCookieJar() func(*Client) {
 	return func(c *Client) {
 		c.client.Jar = globalCookieJar
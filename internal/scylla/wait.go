@@ -0,0 +1,178 @@
+package scylla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+var requestPollInterval = 10 * time.Second
+
+// WaitForClusterRequest polls the cluster request every pollInterval until
+// it is completed and returns the request as last read. It fails if the
+// request fails or ends up in a status it does not know.
+func (c *Client) WaitForClusterRequest(ctx context.Context, requestID int64, pollInterval time.Duration) (*model.ClusterRequest, error) {
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+
+	for {
+		r, err := c.GetClusterRequest(ctx, requestID)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cluster request: %w", err)
+		}
+
+		switch {
+		case strings.EqualFold(r.Status, "COMPLETED"):
+			return &r, nil
+		case strings.EqualFold(r.Status, "QUEUED"), strings.EqualFold(r.Status, "IN_PROGRESS"):
+		case strings.EqualFold(r.Status, "FAILED"):
+			return nil, fmt.Errorf("cluster request failed: %q", r.UserFriendlyError)
+		default:
+			return nil, fmt.Errorf("unrecognized cluster request status: %q", r.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// WaitForClusterStatus polls the cluster every pollInterval until its status
+// equals target and returns the cluster as last read. It fails early if the
+// cluster gets deleted or ends up in an error status.
+func (c *Client) WaitForClusterStatus(ctx context.Context, clusterID int64, target string, pollInterval time.Duration) (*model.Cluster, error) {
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+
+	for {
+		cluster, err := c.GetCluster(ctx, clusterID)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cluster: %w", err)
+		}
+
+		switch status := cluster.Status; {
+		case strings.EqualFold(status, target):
+			return cluster, nil
+		case strings.EqualFold(status, "DELETED"),
+			strings.Contains(strings.ToUpper(status), "ERROR"),
+			strings.Contains(strings.ToUpper(status), "FAILED"):
+			return nil, fmt.Errorf("cluster entered terminal status %q while waiting for %q", status, target)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// WaitForClusterDeletion polls the cluster every pollInterval until it is
+// gone, that is the API reports it as deleted or not found.
+func (c *Client) WaitForClusterDeletion(ctx context.Context, clusterID int64, pollInterval time.Duration) error {
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+
+	for {
+		cluster, err := c.GetCluster(ctx, clusterID)
+		switch {
+		case IsNotFound(err), IsClusterDeletedErr(err):
+			return nil
+		case err != nil:
+			return fmt.Errorf("error reading cluster: %w", err)
+		case strings.EqualFold(cluster.Status, "DELETED"):
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// PollRequests polls the given cluster requests every pollInterval until
+// all of them completed or failed, and returns them keyed by request ID.
+// The API has no batch endpoint, so each cycle reads the pending requests
+// concurrently. If the context is done first, the requests that finished
+// so far are returned along with the context error.
+func (c *Client) PollRequests(ctx context.Context, requestIDs []int64, pollInterval time.Duration) (map[int64]*model.ClusterRequest, error) {
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+
+	var (
+		done    = make(map[int64]*model.ClusterRequest, len(requestIDs))
+		pending = slices.Clone(requestIDs)
+	)
+
+	for len(pending) != 0 {
+		var (
+			mu     sync.Mutex
+			wg     sync.WaitGroup
+			sem    = make(chan struct{}, bulkConcurrency)
+			errs   []error
+			ctxErr error
+		)
+
+		for _, id := range pending {
+			if ctxErr = acquire(ctx, sem); ctxErr != nil {
+				break
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				r, err := c.GetClusterRequest(ctx, id)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch {
+				case err != nil:
+					errs = append(errs, fmt.Errorf("error reading cluster request %d: %w", id, err))
+				case strings.EqualFold(r.Status, "COMPLETED"), strings.EqualFold(r.Status, "FAILED"):
+					done[id] = &r
+				case strings.EqualFold(r.Status, "QUEUED"), strings.EqualFold(r.Status, "IN_PROGRESS"):
+				default:
+					errs = append(errs, fmt.Errorf("unrecognized status of cluster request %d: %q", id, r.Status))
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if err := errors.Join(append(errs, ctxErr)...); err != nil {
+			return done, err
+		}
+
+		pending = slices.DeleteFunc(pending, func(id int64) bool {
+			return done[id] != nil
+		})
+
+		if len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return done, ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	return done, nil
+}
@@ -0,0 +1,128 @@
+package scylla
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scylladb/terraform-provider-scylladbcloud/internal/scylla/model"
+)
+
+func TestWaitForClusterDeletion(t *testing.T) {
+	var polls int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/1", func(w http.ResponseWriter, r *http.Request) {
+		if polls++; polls < 3 {
+			writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 1, Status: "DELETING"}})
+			return
+		}
+		writeError(w, http.StatusNotFound, "Not Found")
+	})
+	mux.HandleFunc("GET /account/1/cluster/2", func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: 2, Status: "DELETING"}})
+	})
+
+	c := newTestClient(t, mux)
+
+	if err := c.WaitForClusterDeletion(context.Background(), 1, time.Millisecond); err != nil {
+		t.Fatalf("WaitForClusterDeletion()=%+v", err)
+	}
+	if polls != 3 {
+		t.Fatalf("want 3 polls, got %d", polls)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := c.WaitForClusterDeletion(ctx, 2, time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %+v", err)
+	}
+}
+
+func TestWaitForClusterStatus(t *testing.T) {
+	statuses := map[int64][]string{
+		1: {"QUEUED", "CREATING", "ACTIVE"},
+		2: {"CREATING", "ERROR"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		status := statuses[id][0]
+		if len(statuses[id]) > 1 {
+			statuses[id] = statuses[id][1:]
+		}
+		writeData(w, model.ClusterDetails{Cluster: model.Cluster{ID: id, Status: status}})
+	})
+
+	c := newTestClient(t, mux)
+
+	cluster, err := c.WaitForClusterStatus(context.Background(), 1, "ACTIVE", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForClusterStatus()=%+v", err)
+	}
+	if cluster.ID != 1 || cluster.Status != "ACTIVE" {
+		t.Fatalf("unexpected cluster: %+v", cluster)
+	}
+
+	if _, err := c.WaitForClusterStatus(context.Background(), 2, "ACTIVE", time.Millisecond); err == nil {
+		t.Fatal("want error for cluster in error status")
+	}
+}
+
+func TestPollRequests(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		polls = make(map[int64]int)
+		// finishAt is the poll on which each request reaches its final status.
+		finishAt = map[int64]int{1: 1, 2: 2, 3: 4}
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account/1/cluster/request/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+
+		mu.Lock()
+		polls[id]++
+		n := polls[id]
+		mu.Unlock()
+
+		status := "IN_PROGRESS"
+		if n >= finishAt[id] {
+			status = "COMPLETED"
+			if id == 2 {
+				status = "FAILED"
+			}
+		}
+		writeData(w, model.ClusterRequest{ID: id, Status: status})
+	})
+
+	c := newTestClient(t, mux)
+
+	reqs, err := c.PollRequests(context.Background(), []int64{1, 2, 3}, time.Millisecond)
+	if err != nil {
+		t.Fatalf("PollRequests()=%+v", err)
+	}
+	if len(reqs) != 3 || reqs[1].Status != "COMPLETED" || reqs[2].Status != "FAILED" || reqs[3].Status != "COMPLETED" {
+		t.Fatalf("unexpected requests: %+v", reqs)
+	}
+	if !reflect.DeepEqual(polls, finishAt) {
+		t.Fatalf("got polls %v, want %v", polls, finishAt)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.PollRequests(ctx, []int64{4, 5}, time.Millisecond); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %+v", err)
+	}
+	if polls[4] != 0 || polls[5] != 0 {
+		t.Fatalf("want no polls after cancellation, got %v", polls)
+	}
+}
@@ -13,10 +13,15 @@ import (
 // Generate the Terraform provider documentation using `tfplugindocs`:
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs generate --examples-dir ./examples --website-source-dir ./templates
 
+// version is set at build time by goreleaser.
+var version = "dev"
+
 func main() {
 	debugFlag := flag.Bool("debug", false, "Start provider in debug mode.")
 	flag.Parse()
 
+	provider.Version = version
+
 	serverFactory, _, err := provider.ProtoV5ProviderServerFactory(context.Background())
 
 	if err != nil {